@@ -3,6 +3,8 @@
 package vgpu
 
 import (
+	"iter"
+
 	"github.com/tomas-mraz/vgpu/enums"
 )
 
@@ -57,6 +59,26 @@ func (i *ImageFlags) HasFlag(f enums.BitFlag) bool { return enums.HasFlag((*int6
 // SetFlag sets the value of the given flags in these flags to the given value.
 func (i *ImageFlags) SetFlag(on bool, f ...enums.BitFlag) { enums.SetFlag((*int64)(i), on, f...) }
 
+// HasAllFlags returns whether these bit flags have all of the given bit flags set.
+func (i *ImageFlags) HasAllFlags(f ...enums.BitFlag) bool {
+	return enums.HasAllFlags((*int64)(i), f...)
+}
+
+// Union returns the union of these bit flags and the given bit flags.
+func (i ImageFlags) Union(f ...ImageFlags) ImageFlags { return enums.Union(i, f...) }
+
+// Intersect returns the intersection of these bit flags and the given bit flags.
+func (i ImageFlags) Intersect(f ...ImageFlags) ImageFlags { return enums.Intersect(i, f...) }
+
+// Difference returns these bit flags without the given bit flags.
+func (i ImageFlags) Difference(f ImageFlags) ImageFlags { return enums.Difference(i, f) }
+
+// Count returns the number of bit flags set in these bit flags.
+func (i ImageFlags) Count() int { return enums.Count(i) }
+
+// Flags returns an iterator over the bit index values of the bit flags set in these bit flags.
+func (i ImageFlags) Flags() iter.Seq[ImageFlags] { return enums.Flags(i) }
+
 // MarshalText implements the [encoding.TextMarshaler] interface.
 func (i ImageFlags) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
 
@@ -65,6 +87,8 @@ func (i *ImageFlags) UnmarshalText(text []byte) error {
 	return enums.UnmarshalText(i, text, "ImageFlags")
 }
 
+var _ = enums.Register[ImageFlags]("vgpu.ImageFlags")
+
 var _BuffTypesValues = []BuffTypes{0, 1, 2, 3}
 
 // BuffTypesN is the highest valid value for type BuffTypes, plus one.
@@ -108,6 +132,8 @@ func (i *BuffTypes) UnmarshalText(text []byte) error {
 	return enums.UnmarshalText(i, text, "BuffTypes")
 }
 
+var _ = enums.Register[BuffTypes]("vgpu.BuffTypes")
+
 var _OptionStatesValues = []OptionStates{0, 1, 2, 3}
 
 // OptionStatesN is the highest valid value for type OptionStates, plus one.
@@ -151,6 +177,8 @@ func (i *OptionStates) UnmarshalText(text []byte) error {
 	return enums.UnmarshalText(i, text, "OptionStates")
 }
 
+var _ = enums.Register[OptionStates]("vgpu.OptionStates")
+
 var _CPUOptionsValues = []CPUOptions{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54}
 
 // CPUOptionsN is the highest valid value for type CPUOptions, plus one.
@@ -194,6 +222,8 @@ func (i *CPUOptions) UnmarshalText(text []byte) error {
 	return enums.UnmarshalText(i, text, "CPUOptions")
 }
 
+var _ = enums.Register[CPUOptions]("vgpu.CPUOptions")
+
 var _VarRolesValues = []VarRoles{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
 
 // VarRolesN is the highest valid value for type VarRoles, plus one.
@@ -235,6 +265,8 @@ func (i VarRoles) MarshalText() ([]byte, error) { return []byte(i.String()), nil
 // UnmarshalText implements the [encoding.TextUnmarshaler] interface.
 func (i *VarRoles) UnmarshalText(text []byte) error { return enums.UnmarshalText(i, text, "VarRoles") }
 
+var _ = enums.Register[VarRoles]("vgpu.VarRoles")
+
 var _SamplerModesValues = []SamplerModes{0, 1, 2, 3, 4}
 
 // SamplerModesN is the highest valid value for type SamplerModes, plus one.
@@ -278,6 +310,8 @@ func (i *SamplerModes) UnmarshalText(text []byte) error {
 	return enums.UnmarshalText(i, text, "SamplerModes")
 }
 
+var _ = enums.Register[SamplerModes]("vgpu.SamplerModes")
+
 var _BorderColorsValues = []BorderColors{0, 1, 2}
 
 // BorderColorsN is the highest valid value for type BorderColors, plus one.
@@ -321,6 +355,8 @@ func (i *BorderColors) UnmarshalText(text []byte) error {
 	return enums.UnmarshalText(i, text, "BorderColors")
 }
 
+var _ = enums.Register[BorderColors]("vgpu.BorderColors")
+
 var _TypesValues = []Types{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23}
 
 // TypesN is the highest valid value for type Types, plus one.
@@ -360,6 +396,8 @@ func (i Types) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
 // UnmarshalText implements the [encoding.TextUnmarshaler] interface.
 func (i *Types) UnmarshalText(text []byte) error { return enums.UnmarshalText(i, text, "Types") }
 
+var _ = enums.Register[Types]("vgpu.Types")
+
 var _ValueFlagsValues = []ValueFlags{0, 1, 2}
 
 // ValueFlagsN is the highest valid value for type ValueFlags, plus one.
@@ -411,6 +449,26 @@ func (i *ValueFlags) HasFlag(f enums.BitFlag) bool { return enums.HasFlag((*int6
 // SetFlag sets the value of the given flags in these flags to the given value.
 func (i *ValueFlags) SetFlag(on bool, f ...enums.BitFlag) { enums.SetFlag((*int64)(i), on, f...) }
 
+// HasAllFlags returns whether these bit flags have all of the given bit flags set.
+func (i *ValueFlags) HasAllFlags(f ...enums.BitFlag) bool {
+	return enums.HasAllFlags((*int64)(i), f...)
+}
+
+// Union returns the union of these bit flags and the given bit flags.
+func (i ValueFlags) Union(f ...ValueFlags) ValueFlags { return enums.Union(i, f...) }
+
+// Intersect returns the intersection of these bit flags and the given bit flags.
+func (i ValueFlags) Intersect(f ...ValueFlags) ValueFlags { return enums.Intersect(i, f...) }
+
+// Difference returns these bit flags without the given bit flags.
+func (i ValueFlags) Difference(f ValueFlags) ValueFlags { return enums.Difference(i, f) }
+
+// Count returns the number of bit flags set in these bit flags.
+func (i ValueFlags) Count() int { return enums.Count(i) }
+
+// Flags returns an iterator over the bit index values of the bit flags set in these bit flags.
+func (i ValueFlags) Flags() iter.Seq[ValueFlags] { return enums.Flags(i) }
+
 // MarshalText implements the [encoding.TextMarshaler] interface.
 func (i ValueFlags) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
 
@@ -418,3 +476,5 @@ func (i ValueFlags) MarshalText() ([]byte, error) { return []byte(i.String()), n
 func (i *ValueFlags) UnmarshalText(text []byte) error {
 	return enums.UnmarshalText(i, text, "ValueFlags")
 }
+
+var _ = enums.Register[ValueFlags]("vgpu.ValueFlags")
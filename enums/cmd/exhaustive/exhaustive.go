@@ -0,0 +1,16 @@
+// Copyright (c) 2024, Cogent Core. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Command exhaustive runs the [exhaustive.Analyzer], which reports
+// switch statements and maps that are missing values of enum types.
+// It can be run directly on packages (e.g., exhaustive ./...), or
+// as a go vet tool (e.g., go vet -vettool=$(which exhaustive) ./...).
+package main
+
+import (
+	"github.com/tomas-mraz/vgpu/enums/exhaustive"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() { singlechecker.Main(exhaustive.Analyzer) }
@@ -11,7 +11,10 @@
 
 package enumgen
 
-import "text/template"
+import (
+	"fmt"
+	"text/template"
+)
 
 // BuildBitFlagMethods builds methods specific to bit flag types.
 func (g *Generator) BuildBitFlagMethods(runs []Value, typ *Type) {
@@ -19,6 +22,7 @@ func (g *Generator) BuildBitFlagMethods(runs []Value, typ *Type) {
 
 	g.ExecTmpl(HasFlagMethodTmpl, typ)
 	g.ExecTmpl(SetFlagMethodTmpl, typ)
+	g.ExecTmpl(SetAlgebraMethodsTmpl, typ)
 }
 
 var HasFlagMethodTmpl = template.Must(template.New("HasFlagMethod").Parse(
@@ -31,6 +35,26 @@ var SetFlagMethodTmpl = template.Must(template.New("SetFlagMethod").Parse(
 func (i *{{.Name}}) SetFlag(on bool, f ...enums.BitFlag) { enums.SetFlag((*int64)(i), on, f...) }
 `))
 
+var SetAlgebraMethodsTmpl = template.Must(template.New("SetAlgebraMethods").Parse(`
+// HasAllFlags returns whether these bit flags have all of the given bit flags set.
+func (i *{{.Name}}) HasAllFlags(f ...enums.BitFlag) bool { return enums.HasAllFlags((*int64)(i), f...) }
+
+// Union returns the union of these bit flags and the given bit flags.
+func (i {{.Name}}) Union(f ...{{.Name}}) {{.Name}} { return enums.Union(i, f...) }
+
+// Intersect returns the intersection of these bit flags and the given bit flags.
+func (i {{.Name}}) Intersect(f ...{{.Name}}) {{.Name}} { return enums.Intersect(i, f...) }
+
+// Difference returns these bit flags without the given bit flags.
+func (i {{.Name}}) Difference(f {{.Name}}) {{.Name}} { return enums.Difference(i, f) }
+
+// Count returns the number of bit flags set in these bit flags.
+func (i {{.Name}}) Count() int { return enums.Count(i) }
+
+// Flags returns an iterator over the bit index values of the bit flags set in these bit flags.
+func (i {{.Name}}) Flags() iter.Seq[{{.Name}}] { return enums.Flags(i) }
+`))
+
 var StringMethodBitFlagTmpl = template.Must(template.New("StringMethodBitFlag").Parse(
 	`// String returns the string representation of this {{.Name}} value.
 func (i {{.Name}}) String() string {
@@ -49,6 +73,73 @@ var SetStringOrMethodBitFlagTmpl = template.Must(template.New("SetStringOrMethod
 // while preserving any bit flags already set, and returns an
 // error if the string is invalid.
 func (i *{{.Name}}) SetStringOr(s string) error {
+	{{- if .Deprecated}} enums.WarnDeprecated(s, _{{.Name}}DeprecatedMap, "{{.Name}}"); {{end}}
 	{{- if eq .Extends ""}} return enums.SetStringOr{{if .Config.AcceptLower}}Lower{{end}}(i, s, _{{.Name}}ValueMap, "{{.Name}}")
 	{{- else}} return enums.SetStringOr{{if .Config.AcceptLower}}Lower{{end}}Extended(i, (*{{.Extends}})(i), s, _{{.Name}}ValueMap) {{end}} }
 `))
+
+// BuildWideBitFlagMethods builds the methods for an array-backed bit flag
+// type, along with the standard enum methods for its bit index type
+// ([Config.Index]), which it registers with [Generator.BuildRegister].
+// The array-backed type itself is registered once by [Generator.Generate],
+// as for all other types. It returns an error if there are more flags than
+// the number of bits in the type.
+func (g *Generator) BuildWideBitFlagMethods(values []Value, typ *Type) error {
+	it := &Type{Name: typ.Config.Index, Config: typ.Config}
+	g.BuildBasicMethods(values, it)
+	g.ExecTmpl(BitIndexStringMethodTmpl, it)
+	if typ.Config.Text {
+		g.BuildTextMethods(values, it)
+	}
+	g.BuildRegister(it)
+	typ.MaxValueP1 = it.MaxValueP1
+	typ.Deprecated = it.Deprecated
+	if typ.MaxValueP1 > 64*typ.Words {
+		return fmt.Errorf("bit flag enum type %s has %d flags, which is more than the %d bits in [%d]uint64", typ.Name, typ.MaxValueP1, 64*typ.Words, typ.Words)
+	}
+	g.ExecTmpl(WideBitFlagMethodsTmpl, typ)
+	return nil
+}
+
+var BitIndexStringMethodTmpl = template.Must(template.New("BitIndexStringMethod").Parse(
+	`// BitIndexString returns the string representation of this {{.Name}} bit index value.
+func (i {{.Name}}) BitIndexString() string { return i.String() }
+`))
+
+var WideBitFlagMethodsTmpl = template.Must(template.New("WideBitFlagMethods").Parse(`
+// String returns the string representation of this {{.Name}} value.
+func (i {{.Name}}) String() string { return enums.BitFlagStringWide(i[:], _{{.Config.Index}}Values) }
+
+// BitIndexString returns the string representation of this {{.Name}} value,
+// which is the same as its String, as its bit index values are of type {{.Config.Index}}.
+func (i {{.Name}}) BitIndexString() string { return i.String() }
+
+// SetString sets the {{.Name}} value from its string representation,
+// and returns an error if the string is invalid.
+func (i *{{.Name}}) SetString(s string) error { *i = {{.Name}}{}; return i.SetStringOr(s) }
+
+// SetStringOr sets the {{.Name}} value from its string representation
+// while preserving any bit flags already set, and returns an
+// error if the string is invalid.
+func (i *{{.Name}}) SetStringOr(s string) error {
+	{{- if .Deprecated}} enums.WarnDeprecated(s, _{{.Config.Index}}DeprecatedMap, "{{.Name}}"); {{end}} return enums.SetStringOr{{if .Config.AcceptLower}}Lower{{end}}(i, s, _{{.Config.Index}}ValueMap, "{{.Name}}") }
+
+// Int64 returns the first 64 bit flags of the {{.Name}} value as an int64.
+func (i {{.Name}}) Int64() int64 { return int64(i[0]) }
+
+// SetInt64 sets the first 64 bit flags of the {{.Name}} value from an int64,
+// and clears all of the other bit flags.
+func (i *{{.Name}}) SetInt64(in int64) { *i = {{.Name}}{uint64(in)} }
+
+// Desc returns the description of the {{.Name}} value, which is its String.
+func (i {{.Name}}) Desc() string { return i.String() }
+
+// Values returns all possible bit index values for the type {{.Name}}.
+func (i {{.Name}}) Values() []enums.Enum { return enums.Values(_{{.Config.Index}}Values) }
+
+// HasFlag returns whether these bit flags have the given bit flag set.
+func (i *{{.Name}}) HasFlag(f enums.BitFlag) bool { return enums.HasFlagWide(i[:], f) }
+
+// SetFlag sets the value of the given flags in these flags to the given value.
+func (i *{{.Name}}) SetFlag(on bool, f ...enums.BitFlag) { enums.SetFlagWide(i[:], on, f...) }
+`))
@@ -51,10 +51,55 @@ type Config struct { //types:add
 	// whether to generate GraphQL marshaling methods for gqlgen
 	GQL bool
 
+	// whether to generate Set and Type methods that implement the [flag.Value]
+	// and pflag.Value interfaces, so that values can be used as command line flags;
+	// bit flag values can be set with the | separated bit flag names (e.g., a|b)
+	Flag bool
+
+	// whether to generate YAML marshaling methods, which are compatible with
+	// gopkg.in/yaml.v2 and v3 without importing them (TOML and other packages
+	// that support [encoding.TextMarshaler] use the Text methods instead)
+	YAML bool
+
 	// whether to allow enums to extend other enums; this should be on in almost all circumstances,
 	// but can be turned off for specific enum types that extend non-enum types
 	Extend bool `default:"true"`
 
-	// generate gosl:start and gosl:end tags around generated N values.
+	// the name of the integer type of the bit index constants for an
+	// array-backed bit flag type (e.g., [2]uint64), for which enumgen
+	// also generates the standard enum methods
+	Index string
+
+	// whether to generate a //gosl:start tagged block with all of the values,
+	// and the N value, as int32 constants, for use in GPU shader code, and a
+	// matching HLSL include file with the same constants at HLSLOutput.
+	// The constant names are the Go names with TrimPrefix removed, after
+	// AddPrefix if specified, and otherwise the type name and an underscore.
+	// The typed N constant is also wrapped in a //gosl:start tagged block.
 	Gosl bool
+
+	// the HLSL include file location for the Gosl constants, relative to the package
+	// on which enumgen is being called; its base name is the name of the gosl region
+	HLSLOutput string `default:"shaders/enumgen.hlsl"`
+
+	// if specified, the output file location of a JSON Schema with a definition
+	// for each enum type, relative to the package on which enumgen is being called
+	Schema string
+
+	// how to report constants of an enum type with the same value as another
+	// constant of the type, which are ignored: ignore, warn, or error
+	Duplicates string `default:"warn"`
+
+	// how to report gaps between the values of an enum type, which mean that
+	// not every value below the N value is valid: ignore, warn, or error
+	Gaps string `default:"ignore"`
+
+	// how to report values that are outside of the range of the underlying integer
+	// type (including the N value), and bit indexes that are outside of the range
+	// of bits of a bit flag type (0 to 63 for int64): ignore, warn, or error
+	Overflow string `default:"error"`
+
+	// whether to only check that the output files are up to date, printing a
+	// diff of any differences and returning an error, instead of writing them
+	Check bool
 }
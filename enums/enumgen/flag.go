@@ -0,0 +1,21 @@
+// Copyright (c) 2024, Cogent Core. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package enumgen
+
+import "text/template"
+
+var FlagMethodsTmpl = template.Must(template.New("FlagMethods").Parse(
+	`
+// Set implements the [flag.Value] interface{{if .IsBitFlag}}, accepting
+// the | separated names of the bit flags to set (e.g., a|b){{end}}.
+func (i *{{.Name}}) Set(s string) error { return i.SetString(s) }
+
+// Type implements the pflag.Value interface.
+func (i *{{.Name}}) Type() string { return "{{.Name}}" }
+`))
+
+func (g *Generator) BuildFlagMethods(runs []Value, typ *Type) {
+	g.ExecTmpl(FlagMethodsTmpl, typ)
+}
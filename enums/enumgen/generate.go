@@ -9,7 +9,9 @@ package enumgen
 //go:generate core generate
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"cogentcore.org/core/base/generate"
 	"cogentcore.org/core/base/logx"
@@ -59,11 +61,18 @@ func Generate(cfg *Config) error { //types:add
 // It is a simple entry point to enumgen that does all
 // of the steps; for more specific functionality, create
 // a new [Generator] with [NewGenerator] and call methods on it.
+//
+// If [Config.Check] is on, it instead prints a diff for each package
+// whose output files are not up to date, and returns [ErrNotUpToDate]
+// if there are any such packages.
 func GeneratePkgs(cfg *Config, pkgs []*packages.Package) error {
 	g := NewGenerator(cfg, pkgs)
+	var outdated []string
 	for _, pkg := range g.Pkgs {
 		g.Pkg = pkg
 		g.Buf.Reset()
+		g.HLSL.Reset()
+		g.Schema = nil
 		err := g.FindEnumTypes()
 		if err != nil {
 			return fmt.Errorf("enumgen: Generate: error finding enum types for package %q: %w", pkg.Name, err)
@@ -76,10 +85,28 @@ func GeneratePkgs(cfg *Config, pkgs []*packages.Package) error {
 		if err != nil {
 			return fmt.Errorf("enumgen: Generate: error generating code for package %q: %w", pkg.Name, err)
 		}
+		if cfg.Check {
+			d, err := g.Check()
+			if err != nil {
+				return fmt.Errorf("enumgen: Generate: error checking code for package %q: %w", pkg.Name, err)
+			}
+			if d != nil {
+				fmt.Print(string(d))
+				outdated = append(outdated, pkg.PkgPath)
+			}
+			continue
+		}
 		err = g.Write()
 		if err != nil {
 			return fmt.Errorf("enumgen: Generate: error writing code for package %q: %w", pkg.Name, err)
 		}
 	}
+	if len(outdated) > 0 {
+		return fmt.Errorf("enumgen: %w (run enumgen to update them): %s", ErrNotUpToDate, strings.Join(outdated, ", "))
+	}
 	return nil
 }
+
+// ErrNotUpToDate is returned by [GeneratePkgs] in [Config.Check] mode
+// when the generated files are not up to date.
+var ErrNotUpToDate = errors.New("generated files are not up to date")
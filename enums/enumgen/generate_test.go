@@ -5,41 +5,102 @@
 package enumgen
 
 import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io"
+	"log/slog"
 	"os"
+	"regexp"
+	"slices"
 	"strings"
 	"testing"
 
 	"cogentcore.org/core/cli"
+	"github.com/pelletier/go-toml/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/tomas-mraz/vgpu/enums"
 	"github.com/tomas-mraz/vgpu/enums/enumgen/testdata"
+	"golang.org/x/tools/go/packages"
+	"gopkg.in/yaml.v3"
 )
 
 func TestGenerate(t *testing.T) {
 	c := &Config{}
 	assert.NoError(t, cli.SetFromDefaults(c))
 	c.Dir = "./testdata"
+	c.Schema = "enums.schema.json"
 	assert.NoError(t, Generate(c))
-	have, err := os.ReadFile("testdata/enumgen.go")
+	testGolden(t, "testdata/enumgen.go", "testdata/enumgen.golden")
+	testGolden(t, "testdata/shaders/enumgen.hlsl", "testdata/enumgen.hlsl.golden")
+	testGolden(t, "testdata/enums.schema.json", "testdata/enums.schema.golden")
+}
+
+// testGolden tests that the generated file is the same as the
+// expected golden file, after the first line.
+func testGolden(t *testing.T, file, golden string) {
+	have, err := os.ReadFile(file)
 	assert.NoError(t, err)
-	want, err := os.ReadFile("testdata/enumgen.golden")
+	want, err := os.ReadFile(golden)
 	assert.NoError(t, err)
 	// ignore first line, which has "Code generated by" message
 	// that can change based on where go test is run.
 	_, shave, got := strings.Cut(string(have), "\n")
 	if !got {
-		t.Errorf("expected string with newline in testdata/enumgen.go, but got %q", have)
+		t.Errorf("expected string with newline in %s, but got %q", file, have)
 	}
 	_, swant, got := strings.Cut(string(want), "\n")
 	if !got {
-		t.Errorf("expected string with newline in testdata/enumgen.golden, but got %q", want)
+		t.Errorf("expected string with newline in %s, but got %q", golden, want)
 	}
 	swant = strings.ReplaceAll(swant, "\r\n", "\n")
 	if shave != swant {
-		t.Errorf("expected generated file and expected file to be the same after the first line, but they are not (compare ./testdata/enumgen.go and ./testdata/enumgen.golden to see the difference)")
+		t.Errorf("expected generated file and expected file to be the same after the first line, but they are not (compare ./%s and ./%s to see the difference)", file, golden)
 	}
 }
 
+func TestCheck(t *testing.T) {
+	c := &Config{}
+	assert.NoError(t, cli.SetFromDefaults(c))
+	c.Dir = "./testdata"
+	c.Check = true
+	c.Output = "enumgen.golden"
+	assert.NoError(t, Generate(c))
+
+	c.HLSLOutput = "shaders/missing.hlsl"
+	err := Generate(c)
+	assert.ErrorIs(t, err, ErrNotUpToDate)
+	assert.NoFileExists(t, "testdata/shaders/missing.hlsl")
+}
+
+func TestCheckFile(t *testing.T) {
+	fn := "testdata/enumgen.golden"
+	want, err := os.ReadFile(fn)
+	assert.NoError(t, err)
+	_, rest, _ := strings.Cut(string(want), "\n")
+	gen := "// Code generated by \"enumgen -check\"; DO NOT EDIT.\n" + rest
+	assert.Nil(t, CheckFile(fn, []byte(gen)))
+
+	gen = strings.Replace(gen, "const FruitsN Fruits = 7", "const FruitsN Fruits = 8", 1)
+	d := string(CheckFile(fn, []byte(gen)))
+	assert.Contains(t, d, "-const FruitsN Fruits = 7")
+	assert.Contains(t, d, "+const FruitsN Fruits = 8")
+}
+
+func TestGoslName(t *testing.T) {
+	typ := &Type{Name: "NeuronTypes", Config: &Config{TrimPrefix: "Neuron"}}
+	assert.Equal(t, "NeuronTypes_Excite", GoslName(&Value{OriginalName: "NeuronExcite"}, typ))
+	typ.Config.AddPrefix = "NT_"
+	assert.Equal(t, "NT_Excite", GoslName(&Value{OriginalName: "NeuronExcite"}, typ))
+	assert.Equal(t, "NT_Other", GoslName(&Value{OriginalName: "Other"}, typ))
+}
+
+func TestNeuronTypesGosl(t *testing.T) {
+	assert.Equal(t, int32(testdata.NeuronTRC), testdata.NeuronTypes_TRC)
+	assert.Equal(t, int32(testdata.NeuronTypesN), testdata.NeuronTypes_N)
+	assert.Equal(t, int32(testdata.Monday), testdata.DAY_Monday)
+}
+
 func TestFruitsString(t *testing.T) {
 	assert.Equal(t, "Peach", testdata.Peach.String())
 }
@@ -156,3 +217,400 @@ func TestMoreLanguagesValuesGlobal(t *testing.T) {
 	have := testdata.MoreLanguagesN.Values()
 	assert.Equal(t, want, have)
 }
+
+func TestFeaturesWide(t *testing.T) {
+	var _ enums.BitFlagSetter = &testdata.Features{}
+	var val testdata.Features
+	val.SetFlag(true, testdata.FeatureCompute, testdata.FeatureFirst128, testdata.FeatureFloat64)
+	assert.Equal(t, testdata.Features{1 << 1, 1<<0 | 1<<36}, val)
+	assert.True(t, val.HasFlag(testdata.FeatureFloat64))
+	assert.False(t, val.HasFlag(testdata.FeatureLast64))
+	want := "Compute|First128|Float64"
+	assert.Equal(t, want, val.String())
+
+	var have testdata.Features
+	assert.NoError(t, have.SetString("compute|First128|float64"))
+	assert.Equal(t, val, have)
+	assert.Error(t, have.SetString("Compute|Apple"))
+
+	text, err := val.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, want, string(text))
+	have = testdata.Features{}
+	assert.NoError(t, have.UnmarshalText([]byte("Last64|Shaders")))
+	assert.Equal(t, testdata.Features{1<<0 | 1<<63}, have)
+
+	have.SetFlag(false, testdata.FeatureShaders)
+	assert.Equal(t, int64(-1<<63), have.Int64())
+	have.SetInt64(3)
+	assert.Equal(t, "Shaders|Compute", have.String())
+	assert.Equal(t, "Float64", testdata.FeatureFloat64.BitIndexString())
+	assert.Len(t, have.Values(), 5)
+}
+
+func TestWideTooManyFlags(t *testing.T) {
+	g := &Generator{Config: &Config{}, Pkg: &packages.Package{Name: "test"}}
+	typ := &Type{Name: "Options", IsBitFlag: true, Words: 1, Config: &Config{Index: "Option"}}
+	values := []Value{{OriginalName: "OptionA", Name: "A", Value: 0, Str: "0"}, {OriginalName: "OptionZ", Name: "Z", Value: 64, Str: "64"}}
+	assert.ErrorContains(t, g.BuildWideBitFlagMethods(values, typ), "more than the 64 bits")
+	values = values[:1]
+	g.Buf.Reset()
+	assert.NoError(t, g.BuildWideBitFlagMethods(values, typ))
+	// only the bit index type is registered here; Generate registers Options
+	assert.Equal(t, 1, strings.Count(g.Buf.String(), `enums.Register[Option]("test.Option")`))
+	assert.NotContains(t, g.Buf.String(), "enums.Register[Options]")
+}
+
+func TestStatesSetAlgebra(t *testing.T) {
+	var a, b testdata.States
+	a.SetFlag(true, testdata.Enabled, testdata.Focused, testdata.Hovered)
+	b.SetFlag(true, testdata.Focused, testdata.Selected)
+	assert.Equal(t, "enabled|focused|vered|selected", a.Union(b).String())
+	assert.Equal(t, "focused", a.Intersect(b).String())
+	assert.Equal(t, "enabled|vered", a.Difference(b).String())
+	assert.Equal(t, 3, a.Count())
+	assert.True(t, a.HasAllFlags(testdata.Enabled, testdata.Hovered))
+	assert.False(t, a.HasAllFlags(testdata.Enabled, testdata.Selected))
+
+	var flags []testdata.States
+	for f := range a.Flags() {
+		flags = append(flags, f)
+	}
+	assert.Equal(t, []testdata.States{testdata.Enabled, testdata.Focused, testdata.Hovered}, flags)
+}
+
+func TestRegistry(t *testing.T) {
+	typ := enums.TypeByName("testdata.Foods")
+	if assert.NotNil(t, typ) {
+		assert.Equal(t, "testdata.Foods", typ.Name)
+		assert.False(t, typ.IsBitFlag)
+		vals := typ.Values()
+		assert.Len(t, vals, 11) // including the extended Fruits values
+		assert.Equal(t, "Apple", vals[0].String())
+		assert.Equal(t, "Cheese", vals[9].String())
+		assert.Equal(t, testdata.Cheese.Desc(), vals[9].Desc())
+		_, ok := typ.New().(*testdata.Foods)
+		assert.True(t, ok)
+	}
+	assert.Nil(t, enums.TypeByName("testdata.Vegetables"))
+
+	v, err := enums.Parse("testdata.Foods", "Bread")
+	assert.NoError(t, err)
+	assert.Equal(t, testdata.Bread, v)
+	v, err = enums.Parse("testdata.Foods", "Apricot") // extended Fruits value
+	assert.NoError(t, err)
+	assert.Equal(t, testdata.Foods(testdata.Apricot), v)
+	_, err = enums.Parse("testdata.Foods", "Pizza")
+	assert.Error(t, err)
+	_, err = enums.Parse("testdata.Vegetables", "Carrot")
+	assert.Error(t, err)
+
+	v, err = enums.Parse("testdata.Days", "DAY_MONDAY")
+	assert.NoError(t, err)
+	assert.Equal(t, testdata.Monday, v)
+
+	typ = enums.TypeByName("testdata.MoreLanguages")
+	if assert.NotNil(t, typ) {
+		assert.True(t, typ.IsBitFlag)
+		assert.Len(t, typ.Values(), 14)
+	}
+	v, err = enums.Parse("testdata.MoreLanguages", "Go|Perl")
+	assert.NoError(t, err)
+	var want testdata.MoreLanguages
+	want.SetFlag(true, testdata.Go, testdata.Perl)
+	assert.Equal(t, want, v)
+
+	// the array-backed bit flag type and its bit index type are each registered once
+	b, err := os.ReadFile("testdata/enumgen.go")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(b), `enums.Register[Features]("testdata.Features")`))
+	assert.Equal(t, 1, strings.Count(string(b), `enums.Register[Feature]("testdata.Feature")`))
+	typ = enums.TypeByName("testdata.Features")
+	if assert.NotNil(t, typ) {
+		assert.True(t, typ.IsBitFlag)
+		assert.Len(t, typ.Values(), 5)
+		_, ok := typ.New().(*testdata.Features)
+		assert.True(t, ok)
+	}
+	typ = enums.TypeByName("testdata.Feature")
+	if assert.NotNil(t, typ) {
+		assert.False(t, typ.IsBitFlag)
+	}
+	v, err = enums.Parse("testdata.Features", "Compute|Float64")
+	assert.NoError(t, err)
+	assert.Equal(t, testdata.Features{1 << 1, 1 << 36}, v)
+}
+
+func TestSchemaOrder(t *testing.T) {
+	c := &Config{}
+	assert.NoError(t, cli.SetFromDefaults(c))
+	c.Dir = "./testdata"
+	c.Schema = "enums.schema.json"
+	pkgs, err := ParsePackages(c)
+	assert.NoError(t, err)
+	g := NewGenerator(c, pkgs)
+	g.Pkg = pkgs[0]
+	assert.NoError(t, g.FindEnumTypes())
+	// extending types before the types that they extend
+	slices.Reverse(g.Types)
+	_, err = g.Generate()
+	assert.NoError(t, err)
+	have, err := g.SchemaBytes()
+	assert.NoError(t, err)
+	want, err := os.ReadFile("testdata/enums.schema.golden")
+	assert.NoError(t, err)
+	assert.Equal(t, string(want), string(have))
+	assert.Equal(t, g.Schema.Defs["Fruits"].Enum, g.Schema.Defs["Foods"].Enum[:len(g.Schema.Defs["Fruits"].Enum)])
+	assert.Equal(t, g.Schema.Defs["Languages"].Flags, g.Schema.Defs["MoreLanguages"].Flags[:len(g.Schema.Defs["Languages"].Flags)])
+}
+
+func TestSchema(t *testing.T) {
+	b, err := os.ReadFile("testdata/enums.schema.golden")
+	assert.NoError(t, err)
+	var sc Schema
+	assert.NoError(t, json.Unmarshal(b, &sc))
+	assert.Equal(t, SchemaURL, sc.Schema)
+
+	fr := sc.Defs["Fruits"]
+	if assert.NotNil(t, fr) {
+		assert.Equal(t, "Fruits is an enum containing fruits", fr.Description)
+		assert.Len(t, fr.Descriptions, len(fr.Enum))
+		for _, v := range testdata.FruitsValues() {
+			assert.Contains(t, fr.Enum, v.String())
+		}
+	}
+	fd := sc.Defs["Foods"]
+	if assert.NotNil(t, fd) { // includes the extended Fruits values
+		for _, v := range testdata.FoodsValues() {
+			assert.Contains(t, fd.Enum, v.String())
+		}
+	}
+	dy := sc.Defs["Days"]
+	if assert.NotNil(t, dy) {
+		assert.Contains(t, dy.Enum, testdata.Monday.String())
+		assert.Equal(t, "Monday is the second day of the week", dy.Descriptions[slices.Index(dy.Enum, testdata.Monday.String())])
+	}
+
+	st := sc.Defs["States"]
+	if assert.NotNil(t, st) {
+		assert.Empty(t, st.Enum)
+		re := regexp.MustCompile(st.Pattern)
+		var val testdata.States
+		assert.True(t, re.MatchString(val.String()))
+		val.SetFlag(true, testdata.Active, testdata.Hovered, testdata.Focused)
+		assert.True(t, re.MatchString(val.String()))
+		assert.False(t, re.MatchString("focused|hovered"))
+		assert.False(t, re.MatchString("focused|"))
+	}
+	ml := sc.Defs["MoreLanguages"]
+	if assert.NotNil(t, ml) {
+		var val testdata.MoreLanguages
+		val.SetFlag(true, testdata.Go, testdata.Perl)
+		assert.True(t, regexp.MustCompile(ml.Pattern).MatchString(val.String()))
+	}
+	ft := sc.Defs["Features"]
+	if assert.NotNil(t, ft) {
+		val := testdata.Features{1<<1 | 1<<63, 1 << 36}
+		assert.True(t, regexp.MustCompile(ft.Pattern).MatchString(val.String()))
+	}
+}
+
+func TestFlag(t *testing.T) {
+	var _ flag.Value = new(testdata.Fruits)
+	fruit := testdata.Peach
+	var langs testdata.Languages
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(&fruit, "fruit", "the fruit")
+	fs.Var(&langs, "langs", "the languages")
+	assert.Equal(t, "Peach", fs.Lookup("fruit").DefValue)
+	assert.NoError(t, fs.Parse([]string{"-fruit", "apricot", "-langs", "Go|Rust"}))
+	assert.Equal(t, testdata.Apricot, fruit)
+	assert.Equal(t, "Go|Rust", langs.String())
+	assert.Error(t, fs.Parse([]string{"-fruit", "Carrot"}))
+	assert.Error(t, fs.Parse([]string{"-langs", "Go|English"}))
+
+	assert.Equal(t, "Fruits", fruit.Type())
+	assert.Equal(t, "Languages", langs.Type())
+}
+
+func TestYAML(t *testing.T) {
+	type config struct {
+		Fruit testdata.Fruits
+		Langs testdata.Languages
+	}
+	c := config{Fruit: testdata.Blueberry}
+	c.Langs.SetFlag(true, testdata.Go, testdata.Swift)
+	b, err := yaml.Marshal(c)
+	assert.NoError(t, err)
+	assert.Equal(t, "fruit: Blueberry\nlangs: Go|Swift\n", string(b))
+	var have config
+	assert.NoError(t, yaml.Unmarshal(b, &have))
+	assert.Equal(t, c, have)
+
+	// invalid names are logged, as with the Text methods
+	have = config{}
+	assert.NoError(t, yaml.Unmarshal([]byte("fruit: Carrot\n"), &have))
+	assert.Equal(t, testdata.Apple, have.Fruit)
+	assert.Error(t, yaml.Unmarshal([]byte("fruit: [Apple]\n"), &have))
+
+	// TOML uses the Text methods
+	b, err = toml.Marshal(c)
+	assert.NoError(t, err)
+	assert.Equal(t, "Fruit = 'Blueberry'\nLangs = 'Go|Swift'\n", string(b))
+	have = config{}
+	assert.NoError(t, toml.Unmarshal(b, &have))
+	assert.Equal(t, c, have)
+}
+
+func TestAliases(t *testing.T) {
+	var f testdata.Fruits
+	for _, s := range []string{"Blueberry", "Bluberry", "bluberry", "BlueBerry"} {
+		f = testdata.Apple
+		assert.NoError(t, f.SetString(s))
+		assert.Equal(t, testdata.Blueberry, f)
+		assert.Equal(t, "Blueberry", f.String())
+	}
+	assert.Len(t, f.Values(), 7)
+	assert.Len(t, testdata.FruitsValues(), 7)
+
+	var d testdata.Days
+	assert.NoError(t, d.SetString("DAY_SUN"))
+	assert.Equal(t, testdata.Sunday, d)
+	assert.Equal(t, "Sunday is the first day of the week", d.Desc())
+	assert.Error(t, d.SetString("Sun"))
+
+	var l testdata.Languages
+	assert.NoError(t, l.SetString("Go|ECMAScript"))
+	assert.Equal(t, "Go|JavaScript", l.String())
+
+	var fs testdata.Features
+	assert.NoError(t, fs.SetString("double|Compute"))
+	assert.Equal(t, "Compute|Float64", fs.String())
+
+	values := []Value{{OriginalName: "A", Name: "A"}, {OriginalName: "B", Name: "B", Aliases: []string{"A"}}}
+	assert.ErrorContains(t, CheckAliases(values, &Type{Name: "T"}), `alias "A" of B of type T is already a name of A`)
+}
+
+func TestDeprecated(t *testing.T) {
+	var buf bytes.Buffer
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+		if a.Key == slog.TimeKey {
+			return slog.Attr{}
+		}
+		return a
+	}})))
+
+	var f testdata.Fruits
+	assert.NoError(t, f.SetString("Peach"))
+	assert.Empty(t, buf.String())
+	assert.NoError(t, f.SetString("apricot"))
+	assert.Equal(t, testdata.Apricot, f)
+	assert.Equal(t, "level=WARN msg=\"deprecated enum value\" type=Fruits value=apricot\n", buf.String())
+
+	buf.Reset()
+	var l testdata.Languages
+	assert.NoError(t, l.SetString("Go|ObjectiveC|Rust"))
+	assert.Equal(t, "Go|Rust|ObjectiveC", l.String())
+	assert.Equal(t, "level=WARN msg=\"deprecated enum value\" type=Languages value=ObjectiveC\n", buf.String())
+
+	buf.Reset()
+	var s testdata.States
+	assert.NoError(t, s.SetString("focused|selected"))
+	assert.Equal(t, "selected", testdata.Selected.BitIndexString())
+	assert.Contains(t, buf.String(), "type=States value=selected")
+
+	buf.Reset()
+	var fs testdata.Features
+	assert.NoError(t, fs.SetString("Last64"))
+	assert.Contains(t, buf.String(), "type=Features value=Last64")
+
+	buf.Reset()
+	var m testdata.MoreLanguages
+	assert.NoError(t, m.SetString("ObjectiveC|Perl"))
+	assert.Contains(t, buf.String(), "type=Languages value=ObjectiveC")
+}
+
+func TestValidateValues(t *testing.T) {
+	c := &Config{}
+	assert.NoError(t, cli.SetFromDefaults(c))
+	c.Dir = "./testdata/validate"
+	pkgs, err := ParsePackages(c)
+	assert.NoError(t, err)
+	g := NewGenerator(c, pkgs)
+	g.Pkg = pkgs[0]
+	assert.NoError(t, g.FindEnumTypes())
+
+	var buf bytes.Buffer
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	want := map[string]string{
+		"Dups":    "DupC has the same value 1 as DupB of type Dups, so it is ignored; use an enums:alias directive on DupB to accept its name in SetString",
+		"Gaps":    "type Gaps has 2 gap(s) between its values, starting between GapA (0) and GapB (2), so not every value below GapsN is valid",
+		"Small":   "the highest value of type Small plus one, SmallN, is outside of the range [0, 255] of the type",
+		"Big":     "bit index BigZ = 64 of bit flag type Big is outside of the range [0, 63] of bits in the type",
+		"Warned":  "",
+		"Invalid": `invalid Gaps level "fatal" for type Invalid; must be "ignore", "warn", or "error"`,
+	}
+	typs := g.Types
+	assert.Len(t, typs, len(want))
+	for _, typ := range typs {
+		g.Types = []*Type{typ}
+		g.Buf.Reset()
+		_, err := g.Generate()
+		if want[typ.Name] == "" {
+			assert.NoError(t, err, typ.Name)
+		} else {
+			assert.ErrorContains(t, err, want[typ.Name], typ.Name)
+		}
+	}
+	warns := buf.String()
+	assert.Contains(t, warns, "WarnedB has the same value 0 as WarnedA of type Warned")
+	assert.Contains(t, warns, "type Warned has 1 gap(s) between its values")
+	assert.Contains(t, warns, "the highest value of type Warned plus one, WarnedN, is outside of the range [-128, 127] of the type")
+	assert.Equal(t, 3, strings.Count(warns, "level=WARN"))
+}
+
+func TestValidateValuesDefaultLevels(t *testing.T) {
+	c := &Config{}
+	assert.NoError(t, cli.SetFromDefaults(c))
+	c.Dir = "./testdata/validate"
+	pkgs, err := ParsePackages(c)
+	assert.NoError(t, err)
+	g := NewGenerator(c, pkgs)
+	g.Pkg = pkgs[0]
+	assert.NoError(t, g.FindEnumTypes())
+
+	var buf bytes.Buffer
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	// empty levels are the defaults: warn, ignore, and error
+	want := map[string]string{
+		"Dups":  "",
+		"Gaps":  "",
+		"Small": "the highest value of type Small plus one, SmallN, is outside of the range [0, 255] of the type",
+	}
+	for _, typ := range g.Types {
+		if _, ok := want[typ.Name]; !ok {
+			continue
+		}
+		tc := *typ.Config
+		tc.Duplicates, tc.Gaps, tc.Overflow = "", "", ""
+		typ.Config = &tc
+		g.Types = []*Type{typ}
+		g.Buf.Reset()
+		_, err := g.Generate()
+		if want[typ.Name] == "" {
+			assert.NoError(t, err, typ.Name)
+		} else {
+			assert.ErrorContains(t, err, want[typ.Name], typ.Name)
+		}
+	}
+	warns := buf.String()
+	assert.Contains(t, warns, "DupC has the same value 1 as DupB of type Dups")
+	assert.NotContains(t, warns, "gap(s)")
+	assert.Equal(t, 1, strings.Count(warns, "level=WARN"))
+}
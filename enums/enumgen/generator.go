@@ -22,11 +22,13 @@ import (
 	"html"
 	"log/slog"
 	"os"
+	"slices"
 	"strings"
 	"text/template"
 
 	"cogentcore.org/core/base/generate"
 	"cogentcore.org/core/cli"
+	"github.com/tomas-mraz/vgpu/gosl/diff"
 	"golang.org/x/tools/go/packages"
 )
 
@@ -35,6 +37,8 @@ import (
 type Generator struct {
 	Config *Config             // The configuration information
 	Buf    bytes.Buffer        // The accumulated output.
+	HLSL   bytes.Buffer        // The accumulated HLSL output of the Gosl constants.
+	Schema *Schema             // The JSON Schema of the enum types, if [Config.Schema] is set.
 	Pkgs   []*packages.Package // The packages we are scanning.
 	Pkg    *packages.Package   // The packages we are currently on.
 	Types  []*Type             // The enum types
@@ -74,7 +78,7 @@ func (g *Generator) FindEnumTypes() error {
 }
 
 // AllowedEnumTypes are the types that can be used for enums
-// that are not bit flags (bit flags can only be int64s).
+// that are not bit flags (bit flags can only be int64s or arrays of uint64s).
 // It is stored as a map for quick and convenient access.
 var AllowedEnumTypes = map[string]bool{"int": true, "int64": true, "int32": true, "int16": true, "int8": true, "uint": true, "uint64": true, "uint32": true, "uint16": true, "uint8": true}
 
@@ -136,10 +140,20 @@ func (g *Generator) InspectForType(n ast.Node) (bool, error) {
 			}
 			tt.IsBitFlag = false
 		case "bitflag":
+			tt.IsBitFlag = true
+			if at, ok := utyp.(*types.Array); ok && at.Elem().String() == "uint64" {
+				if cfg.Index == "" {
+					return false, fmt.Errorf("array-backed bit flag enum type %s must specify the integer type of its bit index constants with -index", typnm)
+				}
+				if tt.Extends != "" {
+					return false, fmt.Errorf("array-backed bit flag enum type %s can not extend another type", typnm)
+				}
+				tt.Words = at.Len()
+				break
+			}
 			if utyp.String() != "int64" {
-				return false, fmt.Errorf("bit flag enum type %s is not allowed; bit flag enums must be of type int64", typnm)
+				return false, fmt.Errorf("bit flag enum type %s is not allowed; bit flag enums must be of type int64, or an array of uint64 for more than 64 flags", typnm)
 			}
-			tt.IsBitFlag = true
 		}
 		g.Types = append(g.Types, tt)
 
@@ -193,11 +207,28 @@ func (g *Generator) Generate() (bool, error) {
 
 		g.PrefixValueNames(values, typ.Config)
 
+		err = g.ValidateValues(values, typ)
+		if err != nil {
+			return true, fmt.Errorf("error validating values: %w", err)
+		}
+
 		values = SortValues(values, typ)
 
-		g.BuildBasicMethods(values, typ)
-		if typ.IsBitFlag {
-			g.BuildBitFlagMethods(values, typ)
+		err = CheckAliases(values, typ)
+		if err != nil {
+			return true, err
+		}
+
+		if typ.Words > 0 {
+			err := g.BuildWideBitFlagMethods(values, typ)
+			if err != nil {
+				return true, err
+			}
+		} else {
+			g.BuildBasicMethods(values, typ)
+			if typ.IsBitFlag {
+				g.BuildBitFlagMethods(values, typ)
+			}
 		}
 
 		if typ.Config.Text {
@@ -209,7 +240,24 @@ func (g *Generator) Generate() (bool, error) {
 		if typ.Config.GQL {
 			g.BuildGQLMethods(values, typ)
 		}
+		if typ.Config.Flag {
+			g.BuildFlagMethods(values, typ)
+		}
+		if typ.Config.YAML {
+			g.BuildYAMLMethods(values, typ)
+		}
+		g.BuildRegister(typ)
+		if g.Config.Schema != "" {
+			g.BuildSchema(values, typ)
+		}
+		if typ.Config.Gosl {
+			err := g.BuildGoslConstants(values, typ)
+			if err != nil {
+				return true, fmt.Errorf("error building gosl constants: %w", err)
+			}
+		}
 	}
+	g.ExtendSchema()
 	return true, nil
 }
 
@@ -224,6 +272,12 @@ func (g *Generator) GenDecl(node ast.Node, file *ast.File, typ *Type) ([]Value,
 		return nil, true, nil
 	}
 	vals := []Value{}
+	// The name of the type of the constants we are looking for,
+	// which is the index type for an array-backed bit flag type.
+	valName := typ.Name
+	if typ.Words > 0 {
+		valName = typ.Config.Index
+	}
 	// The name of the type of the constants we are declaring.
 	// Can change if this is a multi-element declaration.
 	typName := ""
@@ -247,10 +301,14 @@ func (g *Generator) GenDecl(node ast.Node, file *ast.File, typ *Type) ([]Value,
 			}
 			typName = ident.Name
 		}
-		if typName != typ.Name {
+		if typName != valName {
 			// This is not the type we're looking for.
 			continue
 		}
+		aliases, deprecated, err := ValueDirectives(vspec)
+		if err != nil {
+			return nil, false, err
+		}
 		// We now have a list of names (from one line of source code) all being
 		// declared with the desired type.
 		// Grab their names and actual values and store them in f.values.
@@ -288,8 +346,11 @@ func (g *Generator) GenDecl(node ast.Node, file *ast.File, typ *Type) ([]Value,
 				Value:        i64,
 				Signed:       info&types.IsUnsigned == 0,
 				Str:          value.String(),
+				Aliases:      slices.Clone(aliases),
+				Deprecated:   deprecated,
 			}
-			if c := vspec.Comment; typ.Config.LineComment && c != nil && len(c.List) == 1 {
+			// directives are not included in the comment text
+			if c := vspec.Comment; typ.Config.LineComment && c != nil && len(c.List) == 1 && strings.TrimSpace(c.Text()) != "" {
 				v.Name = strings.TrimSpace(c.Text())
 			}
 
@@ -299,6 +360,42 @@ func (g *Generator) GenDecl(node ast.Node, file *ast.File, typ *Type) ([]Value,
 	return vals, false, nil
 }
 
+// ValueDirectives returns the aliases and whether the constants are deprecated
+// from any enums:alias and enums:deprecated comment directives in the doc or
+// line comment of the given constant declaration, and an error if there is
+// an invalid enums directive.
+func ValueDirectives(vspec *ast.ValueSpec) (aliases []string, deprecated bool, err error) {
+	for _, cg := range []*ast.CommentGroup{vspec.Doc, vspec.Comment} {
+		if cg == nil {
+			continue
+		}
+		for _, c := range cg.List {
+			dir, err := cli.ParseDirective(c.Text)
+			if err != nil {
+				return nil, false, fmt.Errorf("error parsing comment directive %q: %w", c.Text, err)
+			}
+			if dir == nil || dir.Tool != "enums" {
+				continue
+			}
+			switch dir.Directive {
+			case "alias":
+				if len(dir.Args) == 0 {
+					return nil, false, fmt.Errorf("expected at least 1 alias name but got 0 (from directive %q)", c.Text)
+				}
+				aliases = append(aliases, dir.Args...)
+			case "deprecated":
+				if len(dir.Args) > 0 {
+					return nil, false, fmt.Errorf("expected 0 arguments but got %d (list: %v) (from directive %q)", len(dir.Args), dir.Args, c.Text)
+				}
+				deprecated = true
+			default:
+				return nil, false, fmt.Errorf("unrecognized enums directive %q for a constant (from %q)", dir.Directive, c.Text)
+			}
+		}
+	}
+	return
+}
+
 // ExecTmpl executes the given template with the given type and
 // writes the result to [Generator.Buf]. It fatally logs any error.
 // All enumgen templates take a [Type] as their data.
@@ -312,7 +409,57 @@ func (g *Generator) ExecTmpl(t *template.Template, typ *Type) {
 
 // Write formats the data in the the Generator's buffer
 // ([Generator.Buf]) and writes it to the file specified by
-// [Generator.Config.Output].
+// [Generator.Config.Output], and writes any HLSL output
+// with [Generator.WriteHLSL] and JSON Schema with [Generator.WriteSchema].
 func (g *Generator) Write() error {
-	return generate.Write(generate.Filepath(g.Pkg, g.Config.Output), g.Buf.Bytes(), nil)
+	err := generate.Write(generate.Filepath(g.Pkg, g.Config.Output), g.Buf.Bytes(), nil)
+	if err != nil {
+		return err
+	}
+	err = g.WriteHLSL()
+	if err != nil {
+		return err
+	}
+	return g.WriteSchema()
+}
+
+// Check formats the data in the Generator's buffer ([Generator.Buf])
+// and compares it with the existing file specified by [Generator.Config.Output],
+// along with any HLSL and JSON Schema output, without writing anything. It returns a unified
+// diff of the existing and generated files, which is nil if they are the same.
+// The first "Code generated by" line is ignored, as it depends on the
+// command line arguments.
+func (g *Generator) Check() ([]byte, error) {
+	fn := generate.Filepath(g.Pkg, g.Config.Output)
+	b, err := generate.Format(fn, g.Buf.Bytes(), nil)
+	if err != nil {
+		return nil, err
+	}
+	d := CheckFile(fn, b)
+	if hb := g.HLSLBytes(); hb != nil {
+		d = append(d, CheckFile(generate.Filepath(g.Pkg, g.Config.HLSLOutput), hb)...)
+	}
+	sb, err := g.SchemaBytes()
+	if err != nil {
+		return nil, err
+	}
+	if sb != nil {
+		d = append(d, CheckFile(generate.Filepath(g.Pkg, g.Config.Schema), sb)...)
+	}
+	return d, nil
+}
+
+// CheckFile returns a unified diff of the existing file with the given
+// filename and the given generated content for it, which is nil if they
+// are the same, ignoring the first line if both start with a
+// "Code generated by" line. A missing file is treated as empty.
+func CheckFile(filename string, gen []byte) []byte {
+	old, _ := os.ReadFile(filename)
+	hdr := []byte("// Code generated by ")
+	if bytes.HasPrefix(old, hdr) && bytes.HasPrefix(gen, hdr) {
+		if oi, gi := bytes.IndexByte(old, '\n'), bytes.IndexByte(gen, '\n'); oi >= 0 && gi >= 0 {
+			gen = append(bytes.Clone(old[:oi]), gen[gi:]...)
+		}
+	}
+	return diff.Diff(filename, old, filename+" (generated)", gen)
 }
@@ -0,0 +1,131 @@
+// Copyright (c) 2024, Cogent Core. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package enumgen
+
+import (
+	"bytes"
+	"fmt"
+	"go/token"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"cogentcore.org/core/base/generate"
+)
+
+// GoslPrefix returns the prefix for the names of the gosl constants
+// of the given type: [Config.AddPrefix] if specified, and otherwise
+// the type name followed by an underscore, so that the names are
+// distinct from the Go constants of the type.
+func GoslPrefix(typ *Type) string {
+	if typ.Config.AddPrefix != "" {
+		return typ.Config.AddPrefix
+	}
+	return typ.Name + "_"
+}
+
+// GoslName returns the name of the gosl constant for the given value
+// of the given type: the original Go name of the value with the
+// [Config.TrimPrefix] prefixes removed, after the [GoslPrefix].
+// The [Config.Transform] and [Config.LineComment] names are not used,
+// as they are not necessarily valid identifiers.
+func GoslName(v *Value, typ *Type) string {
+	nm := v.OriginalName
+	for _, prefix := range strings.Split(typ.Config.TrimPrefix, ",") {
+		nm = strings.TrimPrefix(nm, prefix)
+	}
+	return GoslPrefix(typ) + nm
+}
+
+// GoslRegion returns the name of the //gosl:start region for the
+// generated gosl constants, which is the base name of [Config.HLSLOutput]
+// without the extension, so that gosl generates the same file.
+func (g *Generator) GoslRegion() string {
+	return strings.TrimSuffix(filepath.Base(g.Config.HLSLOutput), filepath.Ext(g.Config.HLSLOutput))
+}
+
+// BuildGoslConstants builds a //gosl:start tagged block with all of the
+// values of the given type, and its N value, as int32 constants, for use in
+// GPU shader code, and adds the equivalent HLSL constants to [Generator.HLSL].
+// It returns an error if a name is not a valid identifier, or a value
+// does not fit in an int32.
+func (g *Generator) BuildGoslConstants(values []Value, typ *Type) error {
+	names := make([]string, 0, len(values)+1)
+	vals := make([]int64, 0, len(values)+1)
+	for _, v := range values {
+		if (v.Signed && (v.Value < math.MinInt32 || v.Value > math.MaxInt32)) || (!v.Signed && uint64(v.Value) > math.MaxInt32) {
+			return fmt.Errorf("value %s = %s of type %s does not fit in the int32 gosl constant", v.OriginalName, v.Str, typ.Name)
+		}
+		names = append(names, GoslName(&v, typ))
+		vals = append(vals, v.Value)
+	}
+	names = append(names, GoslPrefix(typ)+"N")
+	vals = append(vals, typ.MaxValueP1)
+	for _, nm := range names {
+		if !token.IsIdentifier(nm) {
+			return fmt.Errorf("gosl constant name %q of type %s is not a valid identifier; use a different AddPrefix", nm, typ.Name)
+		}
+	}
+
+	doc := fmt.Sprintf("// %s values, for use in GPU shader code.\n", typ.Name)
+	region := g.GoslRegion()
+	g.Printf("\n//gosl:start %s\n\n", region)
+	g.Printf("%s", doc)
+	g.Printf("const (\n")
+	for i, nm := range names {
+		g.Printf("\t%s int32 = %d\n", nm, vals[i])
+	}
+	g.Printf(")\n\n//gosl:end %s\n\n", region)
+
+	if g.HLSL.Len() == 0 {
+		g.printHLSLHeader()
+	}
+	fmt.Fprintf(&g.HLSL, "\n%s", doc)
+	for i, nm := range names {
+		fmt.Fprintf(&g.HLSL, "static const int %s = %d;\n", nm, vals[i])
+	}
+	return nil
+}
+
+// printHLSLHeader prints the header for the generated HLSL file,
+// including the include guard, to [Generator.HLSL]. The generator is
+// always named "enumgen", not the command line, so that the file does
+// not change depending on how enumgen is run (e.g., in go test).
+func (g *Generator) printHLSLHeader() {
+	fmt.Fprintf(&g.HLSL, "// Code generated by \"enumgen\"; DO NOT EDIT.\n\n")
+	guard := g.hlslGuard()
+	fmt.Fprintf(&g.HLSL, "#ifndef %s\n#define %s\n", guard, guard)
+}
+
+// hlslGuard returns the name of the include guard macro for the generated
+// HLSL file, which is the same as the one used by gosl.
+func (g *Generator) hlslGuard() string {
+	return "__" + strings.ToUpper(g.GoslRegion()) + "_HLSL__"
+}
+
+// HLSLBytes returns the complete HLSL file for the constants accumulated
+// in [Generator.HLSL], or nil if there are none.
+func (g *Generator) HLSLBytes() []byte {
+	if g.HLSL.Len() == 0 {
+		return nil
+	}
+	return fmt.Appendf(bytes.Clone(g.HLSL.Bytes()), "\n#endif // %s\n", g.hlslGuard())
+}
+
+// WriteHLSL writes the HLSL constants accumulated in [Generator.HLSL], if any,
+// to the file specified by [Generator.Config.HLSLOutput], creating its
+// directory if needed.
+func (g *Generator) WriteHLSL() error {
+	b := g.HLSLBytes()
+	if b == nil {
+		return nil
+	}
+	fn := generate.Filepath(g.Pkg, g.Config.HLSLOutput)
+	if err := os.MkdirAll(filepath.Dir(fn), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(fn, b, 0666)
+}
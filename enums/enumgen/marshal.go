@@ -25,3 +25,16 @@ func (i *{{.Name}}) UnmarshalText(text []byte) error { return enums.UnmarshalTex
 func (g *Generator) BuildTextMethods(runs []Value, typ *Type) {
 	g.ExecTmpl(TextMethodsTmpl, typ)
 }
+
+var YAMLMethodsTmpl = template.Must(template.New("YAMLMethods").Parse(
+	`
+// MarshalYAML implements the [yaml.Marshaler] interface.
+func (i {{.Name}}) MarshalYAML() (any, error) { return i.String(), nil }
+
+// UnmarshalYAML implements the [yaml.Unmarshaler] interface.
+func (i *{{.Name}}) UnmarshalYAML(unmarshal func(any) error) error { return enums.UnmarshalYAML(i, unmarshal, "{{.Name}}") }
+`))
+
+func (g *Generator) BuildYAMLMethods(runs []Value, typ *Type) {
+	g.ExecTmpl(YAMLMethodsTmpl, typ)
+}
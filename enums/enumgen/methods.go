@@ -12,6 +12,7 @@
 package enumgen
 
 import (
+	"slices"
 	"strings"
 	"text/template"
 )
@@ -49,17 +50,11 @@ var NConstantTmpl = template.Must(template.New("StringNConstant").Parse(
 const {{.Name}}N {{.Name}} = {{.MaxValueP1}}
 `))
 
-var NConstantTmplGosl = template.Must(template.New("StringNConstant").Parse(
-	`//gosl:start
-//{{.Name}}N is the highest valid value for type {{.Name}}, plus one.
-const {{.Name}}N {{.Name}} = {{.MaxValueP1}}
-//gosl:end
-`))
-
 var SetStringMethodTmpl = template.Must(template.New("SetStringMethod").Parse(
 	`// SetString sets the {{.Name}} value from its string representation,
 // and returns an error if the string is invalid.
 func (i *{{.Name}}) SetString(s string) error {
+	{{- if .Deprecated}} enums.WarnDeprecated(s, _{{.Name}}DeprecatedMap, "{{.Name}}"); {{end}}
 	{{- if eq .Extends ""}} return enums.SetString{{if .Config.AcceptLower}}Lower{{end}}(i, s, _{{.Name}}ValueMap, "{{.Name}}")
 	{{- else}} return enums.SetString{{if .Config.AcceptLower}}Lower{{end}}Extended(i, (*{{.Extends}})(i), s, _{{.Name}}ValueMap) {{end}} }
 `))
@@ -118,8 +113,12 @@ func (g *Generator) BuildBasicMethods(values []Value, typ *Type) {
 
 	typ.MaxValueP1 = max + 1
 
-	if g.Config.Gosl {
-		g.ExecTmpl(NConstantTmplGosl, typ)
+	if typ.Config.Gosl {
+		// the typed N constant is also available in gosl code,
+		// in addition to the int32 gosl constants
+		g.Printf("//gosl:start %s\n\n", g.GoslRegion())
+		g.ExecTmpl(NConstantTmpl, typ)
+		g.Printf("\n//gosl:end %s\n", g.GoslRegion())
 	} else {
 		g.ExecTmpl(NConstantTmpl, typ)
 	}
@@ -127,6 +126,9 @@ func (g *Generator) BuildBasicMethods(values []Value, typ *Type) {
 	// Print the map between name and value
 	g.PrintValueMap(values, typ)
 
+	// Print the set of deprecated names
+	g.PrintDeprecatedMap(values, typ)
+
 	// Print the map of values to descriptions
 	g.PrintDescMap(values, typ)
 
@@ -149,15 +151,55 @@ func (g *Generator) BuildBasicMethods(values []Value, typ *Type) {
 	}
 }
 
-// PrintValueMap prints the map between name and value
+// BuildRegister builds the registration of the type in the enums
+// registry with [enums.Register], using its package qualified name.
+func (g *Generator) BuildRegister(typ *Type) {
+	g.Printf("\nvar _ = enums.Register[%s](%q)\n", typ.Name, g.Pkg.Name+"."+typ.Name)
+}
+
+// PrintValueMap prints the map between name and value,
+// including the aliases of each value.
 func (g *Generator) PrintValueMap(values []Value, typ *Type) {
 	g.Printf("\nvar _%sValueMap = map[string]%s{", typ.Name, typ.Name)
 	for _, value := range values {
-		g.Printf("`%s`: %s,", value.Name, &value)
+		for _, name := range ValueNames(&value, typ) {
+			g.Printf("`%s`: %s,", name, &value)
+		}
+	}
+	g.Printf("}\n\n")
+}
+
+// ValueNames returns all of the names accepted by SetString for the
+// given value of the given type: its name and aliases, along with
+// their lowercase versions if [Config.AcceptLower] is set.
+func ValueNames(value *Value, typ *Type) []string {
+	var names []string
+	add := func(name string) {
+		if !slices.Contains(names, name) { // avoid duplicate keys
+			names = append(names, name)
+		}
+	}
+	for _, name := range append([]string{value.Name}, value.Aliases...) {
+		add(name)
 		if typ.Config.AcceptLower {
-			l := strings.ToLower(value.Name)
-			if l != value.Name { // avoid duplicate keys
-				g.Printf("`%s`: %s,", l, &value)
+			add(strings.ToLower(name))
+		}
+	}
+	return names
+}
+
+// PrintDeprecatedMap prints the set of the names of deprecated values,
+// if there are any, and sets [Type.Deprecated] accordingly.
+func (g *Generator) PrintDeprecatedMap(values []Value, typ *Type) {
+	typ.Deprecated = slices.ContainsFunc(values, func(v Value) bool { return v.Deprecated })
+	if !typ.Deprecated {
+		return
+	}
+	g.Printf("\nvar _%sDeprecatedMap = map[string]bool{", typ.Name)
+	for _, value := range values {
+		if value.Deprecated {
+			for _, name := range ValueNames(&value, typ) {
+				g.Printf("`%s`: true,", name)
 			}
 		}
 	}
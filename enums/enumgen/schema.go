@@ -0,0 +1,168 @@
+// Copyright (c) 2024, Cogent Core. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package enumgen
+
+import (
+	"encoding/json"
+	"go/ast"
+	"html"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"cogentcore.org/core/base/generate"
+)
+
+// SchemaURL is the JSON Schema dialect of the generated schemas.
+const SchemaURL = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema document with a definition for each
+// enum type in a package, which can be referenced as
+// "<file>#/$defs/<Type>", including from OpenAPI 3.1 documents.
+type Schema struct {
+	Schema string                 `json:"$schema"`
+	Title  string                 `json:"title"`
+	Defs   map[string]*SchemaType `json:"$defs"`
+
+	// extends maps the name of each type in Defs that extends
+	// another type to the name of that type.
+	extends map[string]string
+}
+
+// SchemaType is the JSON Schema of an enum type, which matches
+// the String output of its values.
+type SchemaType struct {
+
+	// Type is always "string".
+	Type string `json:"type"`
+
+	// Description is the doc comment of the enum type.
+	Description string `json:"description,omitempty"`
+
+	// Enum is the list of value names of a standard enum type.
+	Enum []string `json:"enum,omitempty"`
+
+	// Pattern is the regular expression matching the |-separated
+	// value names of a bit flag type.
+	Pattern string `json:"pattern,omitempty"`
+
+	// Flags is the list of bit flag names of a bit flag type.
+	Flags []string `json:"x-enum-flags,omitempty"`
+
+	// Descriptions are the Desc descriptions of each of the
+	// Enum or Flags values, in the same order.
+	Descriptions []string `json:"x-enum-descriptions,omitempty"`
+}
+
+// BuildSchema adds the JSON Schema of the given type with the given
+// values to [Generator.Schema], using the value names after any
+// transformation, so that it matches the String output. The values
+// of an extended type in the same package are added by [Generator.ExtendSchema]
+// after all of the types have been built, so that they do not depend on
+// the order of the types.
+func (g *Generator) BuildSchema(values []Value, typ *Type) {
+	if g.Schema == nil {
+		g.Schema = &Schema{Schema: SchemaURL, Title: g.Pkg.Name + " enums", Defs: map[string]*SchemaType{}, extends: map[string]string{}}
+	}
+	st := &SchemaType{Type: "string", Description: g.TypeDoc(typ)}
+	var names, descs []string
+	for _, v := range values {
+		names = append(names, v.Name)
+		descs = append(descs, html.UnescapeString(v.Desc))
+	}
+	st.Descriptions = descs
+	st.setNames(names, typ.IsBitFlag)
+	g.Schema.Defs[typ.Name] = st
+	if typ.Extends != "" && !strings.Contains(typ.Extends, ".") { // same package
+		g.Schema.extends[typ.Name] = typ.Extends
+	}
+}
+
+// ExtendSchema adds the values of each extended type in the same package
+// to the JSON Schema of each type in [Generator.Schema] that extends it,
+// before the values of that type, including those of any type that the
+// extended type itself extends. It is called by [Generator.Generate].
+func (g *Generator) ExtendSchema() {
+	if g.Schema == nil {
+		return
+	}
+	var extend func(name string) *SchemaType
+	extend = func(name string) *SchemaType {
+		st := g.Schema.Defs[name]
+		base, has := g.Schema.extends[name]
+		if st == nil || !has {
+			return st
+		}
+		ex := extend(base)
+		if _, pending := g.Schema.extends[base]; ex == nil || pending { // not (yet) complete
+			return st
+		}
+		delete(g.Schema.extends, name) // only extend once
+		names := slices.Concat(ex.Enum, ex.Flags, st.Enum, st.Flags)
+		st.Descriptions = slices.Concat(ex.Descriptions, st.Descriptions)
+		st.setNames(names, st.Pattern != "")
+		return st
+	}
+	for name := range g.Schema.Defs {
+		extend(name)
+	}
+}
+
+// setNames sets the Enum names, or the Flags names and Pattern
+// for a bit flag type, of the schema type to the given names.
+func (st *SchemaType) setNames(names []string, bitFlag bool) {
+	if !bitFlag {
+		st.Enum = names
+		return
+	}
+	st.Flags = names
+	qn := make([]string, len(names))
+	for i, nm := range names {
+		qn[i] = regexp.QuoteMeta(nm)
+	}
+	alt := "(" + strings.Join(qn, "|") + ")"
+	st.Pattern = "^(" + alt + `(\|` + alt + ")*)?$"
+}
+
+// TypeDoc returns the doc comment of the given type, with
+// whitespace collapsed as in the value descriptions.
+func (g *Generator) TypeDoc(typ *Type) string {
+	doc := typ.Type.Doc
+	if doc == nil {
+		for _, file := range g.Pkg.Syntax {
+			for _, decl := range file.Decls {
+				gd, ok := decl.(*ast.GenDecl)
+				if ok && len(gd.Specs) == 1 && gd.Specs[0] == typ.Type {
+					doc = gd.Doc
+				}
+			}
+		}
+	}
+	return strings.Join(strings.Fields(doc.Text()), " ")
+}
+
+// SchemaBytes returns the indented JSON of [Generator.Schema],
+// or nil if there is none.
+func (g *Generator) SchemaBytes() ([]byte, error) {
+	if g.Schema == nil {
+		return nil, nil
+	}
+	b, err := json.MarshalIndent(g.Schema, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+// WriteSchema writes the JSON Schema in [Generator.Schema], if any,
+// to the file specified by [Generator.Config.Schema].
+func (g *Generator) WriteSchema() error {
+	b, err := g.SchemaBytes()
+	if b == nil || err != nil {
+		return err
+	}
+	return os.WriteFile(generate.Filepath(g.Pkg, g.Config.Schema), b, 0666)
+}
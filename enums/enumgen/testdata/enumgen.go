@@ -5,6 +5,7 @@ package testdata
 import (
 	"database/sql/driver"
 	"io"
+	"iter"
 	"strconv"
 
 	"github.com/tomas-mraz/vgpu/enums"
@@ -15,7 +16,9 @@ var _FruitsValues = []Fruits{0, 1, 2, 3, 4, 5, 6}
 // FruitsN is the highest valid value for type Fruits, plus one.
 const FruitsN Fruits = 7
 
-var _FruitsValueMap = map[string]Fruits{`Apple`: 0, `apple`: 0, `Orange`: 1, `orange`: 1, `Peach`: 2, `peach`: 2, `Strawberry`: 3, `strawberry`: 3, `Blackberry`: 4, `blackberry`: 4, `Blueberry`: 5, `blueberry`: 5, `Apricot`: 6, `apricot`: 6}
+var _FruitsValueMap = map[string]Fruits{`Apple`: 0, `apple`: 0, `Orange`: 1, `orange`: 1, `Peach`: 2, `peach`: 2, `Strawberry`: 3, `strawberry`: 3, `Blackberry`: 4, `blackberry`: 4, `Blueberry`: 5, `blueberry`: 5, `Bluberry`: 5, `bluberry`: 5, `BlueBerry`: 5, `Apricot`: 6, `apricot`: 6}
+
+var _FruitsDeprecatedMap = map[string]bool{`Apricot`: true, `apricot`: true}
 
 var _FruitsDescMap = map[Fruits]string{0: ``, 1: ``, 2: ``, 3: ``, 4: ``, 5: ``, 6: ``}
 
@@ -27,6 +30,7 @@ func (i Fruits) String() string { return enums.String(i, _FruitsMap) }
 // SetString sets the Fruits value from its string representation,
 // and returns an error if the string is invalid.
 func (i *Fruits) SetString(s string) error {
+	enums.WarnDeprecated(s, _FruitsDeprecatedMap, "Fruits")
 	return enums.SetStringLower(i, s, _FruitsValueMap, "Fruits")
 }
 
@@ -54,6 +58,22 @@ func (i Fruits) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
 // UnmarshalText implements the [encoding.TextUnmarshaler] interface.
 func (i *Fruits) UnmarshalText(text []byte) error { return enums.UnmarshalText(i, text, "Fruits") }
 
+// Set implements the [flag.Value] interface.
+func (i *Fruits) Set(s string) error { return i.SetString(s) }
+
+// Type implements the pflag.Value interface.
+func (i *Fruits) Type() string { return "Fruits" }
+
+// MarshalYAML implements the [yaml.Marshaler] interface.
+func (i Fruits) MarshalYAML() (any, error) { return i.String(), nil }
+
+// UnmarshalYAML implements the [yaml.Unmarshaler] interface.
+func (i *Fruits) UnmarshalYAML(unmarshal func(any) error) error {
+	return enums.UnmarshalYAML(i, unmarshal, "Fruits")
+}
+
+var _ = enums.Register[Fruits]("testdata.Fruits")
+
 var _FoodsValues = []Foods{7, 8, 9, 10}
 
 // FoodsN is the highest valid value for type Foods, plus one.
@@ -98,12 +118,18 @@ func (i Foods) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
 // UnmarshalText implements the [encoding.TextUnmarshaler] interface.
 func (i *Foods) UnmarshalText(text []byte) error { return enums.UnmarshalText(i, text, "Foods") }
 
+var _ = enums.Register[Foods]("testdata.Foods")
+
 var _DaysValues = []Days{-11, -9, -7, -5, -3, -1, 1}
 
+//gosl:start enumgen
+
 // DaysN is the highest valid value for type Days, plus one.
 const DaysN Days = 2
 
-var _DaysValueMap = map[string]Days{`DAY_SATURDAY`: -11, `DAY_FRIDAY`: -9, `DAY_THURSDAY`: -7, `DAY_WEDNESDAY`: -5, `DAY_TUESDAY`: -3, `DAY_MONDAY`: -1, `DAY_SUNDAY`: 1}
+//gosl:end enumgen
+
+var _DaysValueMap = map[string]Days{`DAY_SATURDAY`: -11, `DAY_FRIDAY`: -9, `DAY_THURSDAY`: -7, `DAY_WEDNESDAY`: -5, `DAY_TUESDAY`: -3, `DAY_MONDAY`: -1, `DAY_SUNDAY`: 1, `DAY_SUN`: 1}
 
 var _DaysDescMap = map[Days]string{-11: `Saturday is the seventh day of the week`, -9: `Friday is the sixth day of the week`, -7: `Thursday is the fifth day of the week`, -5: `Wednesday is the fourth day of the week`, -3: `Tuesday is the third day of the week`, -1: `Monday is the second day of the week`, 1: `Sunday is the first day of the week`}
 
@@ -143,6 +169,24 @@ func (i Days) MarshalGQL(w io.Writer) { w.Write([]byte(strconv.Quote(i.String())
 // UnmarshalGQL implements the [graphql.Unmarshaler] interface.
 func (i *Days) UnmarshalGQL(value any) error { return enums.Scan(i, value, "Days") }
 
+var _ = enums.Register[Days]("testdata.Days")
+
+//gosl:start enumgen
+
+// Days values, for use in GPU shader code.
+const (
+	DAY_Saturday  int32 = -11
+	DAY_Friday    int32 = -9
+	DAY_Thursday  int32 = -7
+	DAY_Wednesday int32 = -5
+	DAY_Tuesday   int32 = -3
+	DAY_Monday    int32 = -1
+	DAY_Sunday    int32 = 1
+	DAY_N         int32 = 2
+)
+
+//gosl:end enumgen
+
 var _StatesValues = []States{1, 3, 5, 7, 9, 11, 13}
 
 // StatesN is the highest valid value for type States, plus one.
@@ -150,6 +194,8 @@ const StatesN States = 14
 
 var _StatesValueMap = map[string]States{`enabled`: 1, `not-enabled`: 3, `focused`: 5, `vered`: 7, `currently-being-pressed-by-user`: 9, `actively-focused`: 11, `selected`: 13}
 
+var _StatesDeprecatedMap = map[string]bool{`selected`: true}
+
 var _StatesDescMap = map[States]string{1: `Enabled indicates the widget is enabled`, 3: `Disabled indicates the widget is disabled`, 5: `Focused indicates the widget has keyboard focus`, 7: `Hovered indicates the widget is being hovered over`, 9: `Active indicates the widget is being interacted with`, 11: `ActivelyFocused indicates the widget has active keyboard focus`, 13: `Selected indicates the widget is selected`}
 
 var _StatesMap = map[States]string{1: `enabled`, 3: `not-enabled`, 5: `focused`, 7: `vered`, 9: `currently-being-pressed-by-user`, 11: `actively-focused`, 13: `selected`}
@@ -170,6 +216,7 @@ func (i *States) SetString(s string) error { *i = 0; return i.SetStringOr(s) }
 // while preserving any bit flags already set, and returns an
 // error if the string is invalid.
 func (i *States) SetStringOr(s string) error {
+	enums.WarnDeprecated(s, _StatesDeprecatedMap, "States")
 	return enums.SetStringOr(i, s, _StatesValueMap, "States")
 }
 
@@ -194,18 +241,40 @@ func (i *States) HasFlag(f enums.BitFlag) bool { return enums.HasFlag((*int64)(i
 // SetFlag sets the value of the given flags in these flags to the given value.
 func (i *States) SetFlag(on bool, f ...enums.BitFlag) { enums.SetFlag((*int64)(i), on, f...) }
 
+// HasAllFlags returns whether these bit flags have all of the given bit flags set.
+func (i *States) HasAllFlags(f ...enums.BitFlag) bool { return enums.HasAllFlags((*int64)(i), f...) }
+
+// Union returns the union of these bit flags and the given bit flags.
+func (i States) Union(f ...States) States { return enums.Union(i, f...) }
+
+// Intersect returns the intersection of these bit flags and the given bit flags.
+func (i States) Intersect(f ...States) States { return enums.Intersect(i, f...) }
+
+// Difference returns these bit flags without the given bit flags.
+func (i States) Difference(f States) States { return enums.Difference(i, f) }
+
+// Count returns the number of bit flags set in these bit flags.
+func (i States) Count() int { return enums.Count(i) }
+
+// Flags returns an iterator over the bit index values of the bit flags set in these bit flags.
+func (i States) Flags() iter.Seq[States] { return enums.Flags(i) }
+
 // Value implements the [driver.Valuer] interface.
 func (i States) Value() (driver.Value, error) { return i.String(), nil }
 
 // Scan implements the [sql.Scanner] interface.
 func (i *States) Scan(value any) error { return enums.Scan(i, value, "States") }
 
+var _ = enums.Register[States]("testdata.States")
+
 var _LanguagesValues = []Languages{6, 10, 14, 18, 22, 26, 30, 34, 38, 42, 46, 50, 54}
 
 // LanguagesN is the highest valid value for type Languages, plus one.
 const LanguagesN Languages = 55
 
-var _LanguagesValueMap = map[string]Languages{`Go`: 6, `Python`: 10, `JavaScript`: 14, `Dart`: 18, `Rust`: 22, `Ruby`: 26, `C`: 30, `CPP`: 34, `ObjectiveC`: 38, `Java`: 42, `TypeScript`: 46, `Kotlin`: 50, `Swift`: 54}
+var _LanguagesValueMap = map[string]Languages{`Go`: 6, `Python`: 10, `JavaScript`: 14, `ECMAScript`: 14, `Dart`: 18, `Rust`: 22, `Ruby`: 26, `C`: 30, `CPP`: 34, `ObjectiveC`: 38, `Java`: 42, `TypeScript`: 46, `Kotlin`: 50, `Swift`: 54}
+
+var _LanguagesDeprecatedMap = map[string]bool{`ObjectiveC`: true}
 
 var _LanguagesDescMap = map[Languages]string{6: `Go is the best programming language`, 10: ``, 14: `JavaScript is the worst programming language`, 18: ``, 22: ``, 26: ``, 30: ``, 34: ``, 38: ``, 42: ``, 46: ``, 50: ``, 54: ``}
 
@@ -227,6 +296,7 @@ func (i *Languages) SetString(s string) error { *i = 0; return i.SetStringOr(s)
 // while preserving any bit flags already set, and returns an
 // error if the string is invalid.
 func (i *Languages) SetStringOr(s string) error {
+	enums.WarnDeprecated(s, _LanguagesDeprecatedMap, "Languages")
 	return enums.SetStringOr(i, s, _LanguagesValueMap, "Languages")
 }
 
@@ -251,6 +321,24 @@ func (i *Languages) HasFlag(f enums.BitFlag) bool { return enums.HasFlag((*int64
 // SetFlag sets the value of the given flags in these flags to the given value.
 func (i *Languages) SetFlag(on bool, f ...enums.BitFlag) { enums.SetFlag((*int64)(i), on, f...) }
 
+// HasAllFlags returns whether these bit flags have all of the given bit flags set.
+func (i *Languages) HasAllFlags(f ...enums.BitFlag) bool { return enums.HasAllFlags((*int64)(i), f...) }
+
+// Union returns the union of these bit flags and the given bit flags.
+func (i Languages) Union(f ...Languages) Languages { return enums.Union(i, f...) }
+
+// Intersect returns the intersection of these bit flags and the given bit flags.
+func (i Languages) Intersect(f ...Languages) Languages { return enums.Intersect(i, f...) }
+
+// Difference returns these bit flags without the given bit flags.
+func (i Languages) Difference(f Languages) Languages { return enums.Difference(i, f) }
+
+// Count returns the number of bit flags set in these bit flags.
+func (i Languages) Count() int { return enums.Count(i) }
+
+// Flags returns an iterator over the bit index values of the bit flags set in these bit flags.
+func (i Languages) Flags() iter.Seq[Languages] { return enums.Flags(i) }
+
 // MarshalText implements the [encoding.TextMarshaler] interface.
 func (i Languages) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
 
@@ -259,6 +347,23 @@ func (i *Languages) UnmarshalText(text []byte) error {
 	return enums.UnmarshalText(i, text, "Languages")
 }
 
+// Set implements the [flag.Value] interface, accepting
+// the | separated names of the bit flags to set (e.g., a|b).
+func (i *Languages) Set(s string) error { return i.SetString(s) }
+
+// Type implements the pflag.Value interface.
+func (i *Languages) Type() string { return "Languages" }
+
+// MarshalYAML implements the [yaml.Marshaler] interface.
+func (i Languages) MarshalYAML() (any, error) { return i.String(), nil }
+
+// UnmarshalYAML implements the [yaml.Unmarshaler] interface.
+func (i *Languages) UnmarshalYAML(unmarshal func(any) error) error {
+	return enums.UnmarshalYAML(i, unmarshal, "Languages")
+}
+
+var _ = enums.Register[Languages]("testdata.Languages")
+
 var _MoreLanguagesValues = []MoreLanguages{55}
 
 // MoreLanguagesN is the highest valid value for type MoreLanguages, plus one.
@@ -320,6 +425,26 @@ func (i *MoreLanguages) HasFlag(f enums.BitFlag) bool { return enums.HasFlag((*i
 // SetFlag sets the value of the given flags in these flags to the given value.
 func (i *MoreLanguages) SetFlag(on bool, f ...enums.BitFlag) { enums.SetFlag((*int64)(i), on, f...) }
 
+// HasAllFlags returns whether these bit flags have all of the given bit flags set.
+func (i *MoreLanguages) HasAllFlags(f ...enums.BitFlag) bool {
+	return enums.HasAllFlags((*int64)(i), f...)
+}
+
+// Union returns the union of these bit flags and the given bit flags.
+func (i MoreLanguages) Union(f ...MoreLanguages) MoreLanguages { return enums.Union(i, f...) }
+
+// Intersect returns the intersection of these bit flags and the given bit flags.
+func (i MoreLanguages) Intersect(f ...MoreLanguages) MoreLanguages { return enums.Intersect(i, f...) }
+
+// Difference returns these bit flags without the given bit flags.
+func (i MoreLanguages) Difference(f MoreLanguages) MoreLanguages { return enums.Difference(i, f) }
+
+// Count returns the number of bit flags set in these bit flags.
+func (i MoreLanguages) Count() int { return enums.Count(i) }
+
+// Flags returns an iterator over the bit index values of the bit flags set in these bit flags.
+func (i MoreLanguages) Flags() iter.Seq[MoreLanguages] { return enums.Flags(i) }
+
 // MarshalText implements the [encoding.TextMarshaler] interface.
 func (i MoreLanguages) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
 
@@ -327,3 +452,161 @@ func (i MoreLanguages) MarshalText() ([]byte, error) { return []byte(i.String())
 func (i *MoreLanguages) UnmarshalText(text []byte) error {
 	return enums.UnmarshalText(i, text, "MoreLanguages")
 }
+
+var _ = enums.Register[MoreLanguages]("testdata.MoreLanguages")
+
+var _NeuronTypesValues = []NeuronTypes{0, 1, 2}
+
+//gosl:start enumgen
+
+// NeuronTypesN is the highest valid value for type NeuronTypes, plus one.
+const NeuronTypesN NeuronTypes = 3
+
+//gosl:end enumgen
+
+var _NeuronTypesValueMap = map[string]NeuronTypes{`Excite`: 0, `Inhib`: 1, `TRC`: 2}
+
+var _NeuronTypesDescMap = map[NeuronTypes]string{0: `NeuronExcite are excitatory neurons`, 1: `NeuronInhib are inhibitory neurons`, 2: `NeuronTRC are thalamic relay cell neurons`}
+
+var _NeuronTypesMap = map[NeuronTypes]string{0: `Excite`, 1: `Inhib`, 2: `TRC`}
+
+// String returns the string representation of this NeuronTypes value.
+func (i NeuronTypes) String() string { return enums.String(i, _NeuronTypesMap) }
+
+// SetString sets the NeuronTypes value from its string representation,
+// and returns an error if the string is invalid.
+func (i *NeuronTypes) SetString(s string) error {
+	return enums.SetString(i, s, _NeuronTypesValueMap, "NeuronTypes")
+}
+
+// Int64 returns the NeuronTypes value as an int64.
+func (i NeuronTypes) Int64() int64 { return int64(i) }
+
+// SetInt64 sets the NeuronTypes value from an int64.
+func (i *NeuronTypes) SetInt64(in int64) { *i = NeuronTypes(in) }
+
+// Desc returns the description of the NeuronTypes value.
+func (i NeuronTypes) Desc() string { return enums.Desc(i, _NeuronTypesDescMap) }
+
+// NeuronTypesValues returns all possible values for the type NeuronTypes.
+func NeuronTypesValues() []NeuronTypes { return _NeuronTypesValues }
+
+// Values returns all possible values for the type NeuronTypes.
+func (i NeuronTypes) Values() []enums.Enum { return enums.Values(_NeuronTypesValues) }
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (i NeuronTypes) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (i *NeuronTypes) UnmarshalText(text []byte) error {
+	return enums.UnmarshalText(i, text, "NeuronTypes")
+}
+
+var _ = enums.Register[NeuronTypes]("testdata.NeuronTypes")
+
+//gosl:start enumgen
+
+// NeuronTypes values, for use in GPU shader code.
+const (
+	NeuronTypes_Excite int32 = 0
+	NeuronTypes_Inhib  int32 = 1
+	NeuronTypes_TRC    int32 = 2
+	NeuronTypes_N      int32 = 3
+)
+
+//gosl:end enumgen
+
+var _FeatureValues = []Feature{0, 1, 63, 64, 100}
+
+// FeatureN is the highest valid value for type Feature, plus one.
+const FeatureN Feature = 101
+
+var _FeatureValueMap = map[string]Feature{`Shaders`: 0, `shaders`: 0, `Compute`: 1, `compute`: 1, `Last64`: 63, `last64`: 63, `First128`: 64, `first128`: 64, `Float64`: 100, `float64`: 100, `Double`: 100, `double`: 100}
+
+var _FeatureDeprecatedMap = map[string]bool{`Last64`: true, `last64`: true}
+
+var _FeatureDescMap = map[Feature]string{0: `FeatureShaders are shaders`, 1: `FeatureCompute is compute`, 63: `FeatureLast64 is the last feature in the first 64 bits`, 64: `FeatureFirst128 is the first feature in the second 64 bits`, 100: `FeatureFloat64 is the 100th feature`}
+
+var _FeatureMap = map[Feature]string{0: `Shaders`, 1: `Compute`, 63: `Last64`, 64: `First128`, 100: `Float64`}
+
+// String returns the string representation of this Feature value.
+func (i Feature) String() string { return enums.String(i, _FeatureMap) }
+
+// SetString sets the Feature value from its string representation,
+// and returns an error if the string is invalid.
+func (i *Feature) SetString(s string) error {
+	enums.WarnDeprecated(s, _FeatureDeprecatedMap, "Feature")
+	return enums.SetStringLower(i, s, _FeatureValueMap, "Feature")
+}
+
+// Int64 returns the Feature value as an int64.
+func (i Feature) Int64() int64 { return int64(i) }
+
+// SetInt64 sets the Feature value from an int64.
+func (i *Feature) SetInt64(in int64) { *i = Feature(in) }
+
+// Desc returns the description of the Feature value.
+func (i Feature) Desc() string { return enums.Desc(i, _FeatureDescMap) }
+
+// FeatureValues returns all possible values for the type Feature.
+func FeatureValues() []Feature { return _FeatureValues }
+
+// Values returns all possible values for the type Feature.
+func (i Feature) Values() []enums.Enum { return enums.Values(_FeatureValues) }
+
+// BitIndexString returns the string representation of this Feature bit index value.
+func (i Feature) BitIndexString() string { return i.String() }
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (i Feature) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (i *Feature) UnmarshalText(text []byte) error { return enums.UnmarshalText(i, text, "Feature") }
+
+var _ = enums.Register[Feature]("testdata.Feature")
+
+// String returns the string representation of this Features value.
+func (i Features) String() string { return enums.BitFlagStringWide(i[:], _FeatureValues) }
+
+// BitIndexString returns the string representation of this Features value,
+// which is the same as its String, as its bit index values are of type Feature.
+func (i Features) BitIndexString() string { return i.String() }
+
+// SetString sets the Features value from its string representation,
+// and returns an error if the string is invalid.
+func (i *Features) SetString(s string) error { *i = Features{}; return i.SetStringOr(s) }
+
+// SetStringOr sets the Features value from its string representation
+// while preserving any bit flags already set, and returns an
+// error if the string is invalid.
+func (i *Features) SetStringOr(s string) error {
+	enums.WarnDeprecated(s, _FeatureDeprecatedMap, "Features")
+	return enums.SetStringOrLower(i, s, _FeatureValueMap, "Features")
+}
+
+// Int64 returns the first 64 bit flags of the Features value as an int64.
+func (i Features) Int64() int64 { return int64(i[0]) }
+
+// SetInt64 sets the first 64 bit flags of the Features value from an int64,
+// and clears all of the other bit flags.
+func (i *Features) SetInt64(in int64) { *i = Features{uint64(in)} }
+
+// Desc returns the description of the Features value, which is its String.
+func (i Features) Desc() string { return i.String() }
+
+// Values returns all possible bit index values for the type Features.
+func (i Features) Values() []enums.Enum { return enums.Values(_FeatureValues) }
+
+// HasFlag returns whether these bit flags have the given bit flag set.
+func (i *Features) HasFlag(f enums.BitFlag) bool { return enums.HasFlagWide(i[:], f) }
+
+// SetFlag sets the value of the given flags in these flags to the given value.
+func (i *Features) SetFlag(on bool, f ...enums.BitFlag) { enums.SetFlagWide(i[:], on, f...) }
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (i Features) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (i *Features) UnmarshalText(text []byte) error { return enums.UnmarshalText(i, text, "Features") }
+
+var _ = enums.Register[Features]("testdata.Features")
@@ -0,0 +1,22 @@
+// Code generated by "enumgen"; DO NOT EDIT.
+
+#ifndef __ENUMGEN_HLSL__
+#define __ENUMGEN_HLSL__
+
+// Days values, for use in GPU shader code.
+static const int DAY_Saturday = -11;
+static const int DAY_Friday = -9;
+static const int DAY_Thursday = -7;
+static const int DAY_Wednesday = -5;
+static const int DAY_Tuesday = -3;
+static const int DAY_Monday = -1;
+static const int DAY_Sunday = 1;
+static const int DAY_N = 2;
+
+// NeuronTypes values, for use in GPU shader code.
+static const int NeuronTypes_Excite = 0;
+static const int NeuronTypes_Inhib = 1;
+static const int NeuronTypes_TRC = 2;
+static const int NeuronTypes_N = 3;
+
+#endif // __ENUMGEN_HLSL__
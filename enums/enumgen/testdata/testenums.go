@@ -1,7 +1,7 @@
 package testdata
 
 // Fruits is an enum containing fruits
-type Fruits uint8 //enums:enum -accept-lower -is-valid
+type Fruits uint8 //enums:enum -accept-lower -is-valid -flag -yaml
 
 const (
 	Apple Fruits = iota
@@ -9,8 +9,8 @@ const (
 	Peach
 	Strawberry
 	Blackberry
-	Blueberry
-	Apricot
+	Blueberry   //enums:alias Bluberry BlueBerry
+	Apricot     //enums:deprecated
 	OrangeFruit = Orange
 )
 
@@ -25,10 +25,11 @@ const (
 )
 
 // Days is an enum containing the days of the week
-type Days int32 //enums:enum -transform=SNAKE -addprefix=DAY_ -gql -no-accept-lower
+type Days int32 //enums:enum -transform=SNAKE -addprefix=DAY_ -gql -no-accept-lower -gosl
 
 const (
 	// Sunday is the first day of the week
+	//enums:alias Sun
 	Sunday Days = -2*iota + 1
 	// Monday is the second day of the week
 	Monday
@@ -61,24 +62,25 @@ const (
 	// ActivelyFocused indicates the widget has active keyboard focus
 	ActivelyFocused
 	// Selected indicates the widget is selected
-	Selected
+	Selected //enums:deprecated
 )
 
 // Languages is a bitflag enum containing programming languages
-type Languages int64 //enums:bitflag
+type Languages int64 //enums:bitflag -flag -yaml
 
 const (
 	// Go is the best programming language
 	Go Languages = 4*iota + 6
 	Python
 	// JavaScript is the worst programming language
+	//enums:alias ECMAScript
 	JavaScript
 	Dart
 	Rust
 	Ruby
 	C
 	CPP
-	ObjectiveC
+	ObjectiveC //enums:deprecated
 	Java
 	TypeScript
 	Kotlin
@@ -91,3 +93,35 @@ type MoreLanguages Languages //enums:bitflag -no-accept-lower
 const (
 	Perl MoreLanguages = MoreLanguages(LanguagesN) + iota
 )
+
+// NeuronTypes is an enum containing neuron types, which is used in GPU shader code
+type NeuronTypes int32 //enums:enum -gosl -trim-prefix Neuron
+
+const (
+	// NeuronExcite are excitatory neurons
+	NeuronExcite NeuronTypes = iota
+	// NeuronInhib are inhibitory neurons
+	NeuronInhib
+	// NeuronTRC are thalamic relay cell neurons
+	NeuronTRC
+)
+
+// Features is an array-backed bitflag enum containing more than 64 features
+type Features [2]uint64 //enums:bitflag -index Feature -trim-prefix Feature -accept-lower
+
+// Feature is a bit index of a [Features] flag
+type Feature int32
+
+const (
+	// FeatureShaders are shaders
+	FeatureShaders Feature = iota
+	// FeatureCompute is compute
+	FeatureCompute
+	// FeatureLast64 is the last feature in the first 64 bits
+	FeatureLast64 Feature = 63 //enums:deprecated
+	// FeatureFirst128 is the first feature in the second 64 bits
+	FeatureFirst128 Feature = 64
+	// FeatureFloat64 is the 100th feature
+	//enums:alias FeatureDouble
+	FeatureFloat64 Feature = 100
+)
@@ -0,0 +1,54 @@
+// Package validate contains enum types with values that
+// do not pass the validation checks of enumgen.
+package validate
+
+// Dups has a duplicate value.
+type Dups int32 //enums:enum -duplicates error
+
+const (
+	DupA Dups = iota
+	DupB
+	DupC Dups = 1
+)
+
+// Gaps has gaps between its values.
+type Gaps int32 //enums:enum -gaps error
+
+const (
+	GapA Gaps = 0
+	GapB Gaps = 2
+	GapC Gaps = 3
+	GapD Gaps = 7
+)
+
+// Small has a value that makes its N value overflow.
+type Small uint8 //enums:enum
+
+const (
+	SmallA Small = 0
+	SmallZ Small = 255
+)
+
+// Big has a bit index that does not fit in an int64.
+type Big int64 //enums:bitflag
+
+const (
+	BigA Big = 0
+	BigZ Big = 64
+)
+
+// Warned has all of the problems, but only warns about them.
+type Warned int8 //enums:enum -duplicates warn -gaps warn -overflow warn
+
+const (
+	WarnedA Warned = 0
+	WarnedB Warned = 0
+	WarnedZ Warned = 127
+)
+
+// Invalid has an invalid level.
+type Invalid int32 //enums:enum -gaps fatal
+
+const (
+	InvalidA Invalid = iota
+)
@@ -28,8 +28,10 @@ type Type struct {
 	Name       string        // The name of the type
 	Type       *ast.TypeSpec // The standard AST type value
 	IsBitFlag  bool          // Whether the type is a bit flag type
+	Words      int64         // The number of uint64 words of an array-backed bit flag type (0 otherwise)
 	Extends    string        // The type that this type extends, if any ("" if it doesn't extend)
 	MaxValueP1 int64         // the highest defined value for the type, plus one
+	Deprecated bool          // Whether any of the values of the type are deprecated
 	Config     *Config       // Configuration information set in the comment directive for the type; is initialized to generator config info first
 }
 
@@ -38,6 +40,11 @@ type Value struct {
 	OriginalName string // The name of the constant before transformation
 	Name         string // The name of the constant after transformation (i.e. camel case => snake case)
 	Desc         string // The comment description of the constant
+	// Aliases are other names of the constant, from an enums:alias directive,
+	// which are accepted by SetString but never returned by String.
+	// They are transformed in the same way as Name.
+	Aliases    []string
+	Deprecated bool // Whether the constant is deprecated, from an enums:deprecated directive
 	// The Value is stored as a bit pattern alone. The boolean tells us
 	// whether to interpret it as an int64 or a uint64; the only place
 	// this matters is when sorting.
@@ -80,6 +87,25 @@ func SortValues(values []Value, typ *Type) []Value {
 	return values
 }
 
+// CheckAliases returns an error if any alias of the given values of the
+// given type is the same as the name or another alias of any of the values,
+// as SetString must be able to distinguish between all of the names.
+func CheckAliases(values []Value, typ *Type) error {
+	names := map[string]string{} // to original constant name
+	for _, v := range values {
+		names[v.Name] = v.OriginalName
+	}
+	for _, v := range values {
+		for _, a := range v.Aliases {
+			if on, has := names[a]; has {
+				return fmt.Errorf("alias %q of %s of type %s is already a name of %s", a, v.OriginalName, typ.Name, on)
+			}
+			names[a] = v.OriginalName
+		}
+	}
+	return nil
+}
+
 // TrimValueNames removes the prefixes specified
 // in [Config.TrimPrefix] from each name
 // of the given values.
@@ -87,6 +113,9 @@ func (g *Generator) TrimValueNames(values []Value, c *Config) {
 	for _, prefix := range strings.Split(c.TrimPrefix, ",") {
 		for i := range values {
 			values[i].Name = strings.TrimPrefix(values[i].Name, prefix)
+			for j, a := range values[i].Aliases {
+				values[i].Aliases[j] = strings.TrimPrefix(a, prefix)
+			}
 		}
 	}
 
@@ -98,6 +127,9 @@ func (g *Generator) TrimValueNames(values []Value, c *Config) {
 func (g *Generator) PrefixValueNames(values []Value, c *Config) {
 	for i := range values {
 		values[i].Name = c.AddPrefix + values[i].Name
+		for j, a := range values[i].Aliases {
+			values[i].Aliases[j] = c.AddPrefix + a
+		}
 	}
 }
 
@@ -158,6 +190,9 @@ func (g *Generator) TransformValueNames(values []Value, c *Config) error {
 			return fmt.Errorf("transformation of %q (%s) got an empty result", v.Name, v.OriginalName)
 		}
 		values[i].Name = after
+		for j, a := range v.Aliases {
+			values[i].Aliases[j] = fn(a)
+		}
 	}
 	return nil
 }
@@ -6,6 +6,6 @@ import (
 	"cogentcore.org/core/types"
 )
 
-var _ = types.AddType(&types.Type{Name: "github.com/tomas-mraz/vgpu/enums/enumgen.Config", IDName: "config", Doc: "Config contains the configuration information\nused by enumgen", Directives: []types.Directive{{Tool: "types", Directive: "add"}}, Fields: []types.Field{{Name: "Dir", Doc: "the source directory to run enumgen on (can be set to multiple through paths like ./...)"}, {Name: "Output", Doc: "the output file location relative to the package on which enumgen is being called"}, {Name: "Transform", Doc: "if specified, the enum item transformation method (upper, lower, snake, SNAKE, kebab, KEBAB,\ncamel, lower-camel, title, sentence, first, first-upper, or first-lower)"}, {Name: "TrimPrefix", Doc: "if specified, a comma-separated list of prefixes to trim from each item"}, {Name: "AddPrefix", Doc: "if specified, the prefix to add to each item"}, {Name: "LineComment", Doc: "whether to use line comment text as printed text when present"}, {Name: "AcceptLower", Doc: "whether to accept lowercase versions of enum names in SetString"}, {Name: "IsValid", Doc: "whether to generate a method returning whether a value is\na valid option for its enum type; this must also be set for\nany base enum type being extended"}, {Name: "Text", Doc: "whether to generate text marshaling methods"}, {Name: "SQL", Doc: "whether to generate methods that implement the SQL Scanner and Valuer interfaces"}, {Name: "GQL", Doc: "whether to generate GraphQL marshaling methods for gqlgen"}, {Name: "Extend", Doc: "whether to allow enums to extend other enums; this should be on in almost all circumstances,\nbut can be turned off for specific enum types that extend non-enum types"}, {Name: "Gosl", Doc: "generate gosl:start and gosl:end tags around generated N values."}}})
+var _ = types.AddType(&types.Type{Name: "github.com/tomas-mraz/vgpu/enums/enumgen.Config", IDName: "config", Doc: "Config contains the configuration information\nused by enumgen", Directives: []types.Directive{{Tool: "types", Directive: "add"}}, Fields: []types.Field{{Name: "Dir", Doc: "the source directory to run enumgen on (can be set to multiple through paths like ./...)"}, {Name: "Output", Doc: "the output file location relative to the package on which enumgen is being called"}, {Name: "Transform", Doc: "if specified, the enum item transformation method (upper, lower, snake, SNAKE, kebab, KEBAB,\ncamel, lower-camel, title, sentence, first, first-upper, or first-lower)"}, {Name: "TrimPrefix", Doc: "if specified, a comma-separated list of prefixes to trim from each item"}, {Name: "AddPrefix", Doc: "if specified, the prefix to add to each item"}, {Name: "LineComment", Doc: "whether to use line comment text as printed text when present"}, {Name: "AcceptLower", Doc: "whether to accept lowercase versions of enum names in SetString"}, {Name: "IsValid", Doc: "whether to generate a method returning whether a value is\na valid option for its enum type; this must also be set for\nany base enum type being extended"}, {Name: "Text", Doc: "whether to generate text marshaling methods"}, {Name: "SQL", Doc: "whether to generate methods that implement the SQL Scanner and Valuer interfaces"}, {Name: "GQL", Doc: "whether to generate GraphQL marshaling methods for gqlgen"}, {Name: "Flag", Doc: "whether to generate Set and Type methods that implement the [flag.Value]\nand pflag.Value interfaces, so that values can be used as command line flags;\nbit flag values can be set with the | separated bit flag names (e.g., a|b)"}, {Name: "YAML", Doc: "whether to generate YAML marshaling methods, which are compatible with\ngopkg.in/yaml.v2 and v3 without importing them (TOML and other packages\nthat support [encoding.TextMarshaler] use the Text methods instead)"}, {Name: "Extend", Doc: "whether to allow enums to extend other enums; this should be on in almost all circumstances,\nbut can be turned off for specific enum types that extend non-enum types"}, {Name: "Index", Doc: "the name of the integer type of the bit index constants for an\narray-backed bit flag type (e.g., [2]uint64), for which enumgen\nalso generates the standard enum methods"}, {Name: "Gosl", Doc: "whether to generate a //gosl:start tagged block with all of the values,\nand the N value, as int32 constants, for use in GPU shader code, and a\nmatching HLSL include file with the same constants at HLSLOutput.\nThe constant names are the Go names with TrimPrefix removed, after\nAddPrefix if specified, and otherwise the type name and an underscore.\nThe typed N constant is also wrapped in a //gosl:start tagged block."}, {Name: "HLSLOutput", Doc: "the HLSL include file location for the Gosl constants, relative to the package\non which enumgen is being called; its base name is the name of the gosl region"}, {Name: "Schema", Doc: "if specified, the output file location of a JSON Schema with a definition\nfor each enum type, relative to the package on which enumgen is being called"}, {Name: "Duplicates", Doc: "how to report constants of an enum type with the same value as another\nconstant of the type, which are ignored: ignore, warn, or error"}, {Name: "Gaps", Doc: "how to report gaps between the values of an enum type, which mean that\nnot every value below the N value is valid: ignore, warn, or error"}, {Name: "Overflow", Doc: "how to report values that are outside of the range of the underlying integer\ntype (including the N value), and bit indexes that are outside of the range\nof bits of a bit flag type (0 to 63 for int64): ignore, warn, or error"}, {Name: "Check", Doc: "whether to only check that the output files are up to date, printing a\ndiff of any differences and returning an error, instead of writing them"}}})
 
 var _ = types.AddFunc(&types.Func{Name: "github.com/tomas-mraz/vgpu/enums/enumgen.Generate", Doc: "Generate generates enum methods, using the\nconfiguration information, loading the packages from the\nconfiguration source directory, and writing the result\nto the configuration output file.\n\nIt is a simple entry point to enumgen that does all\nof the steps; for more specific functionality, create\na new [Generator] with [NewGenerator] and call methods on it.", Directives: []types.Directive{{Tool: "cli", Directive: "cmd", Args: []string{"-root"}}, {Tool: "types", Directive: "add"}}, Args: []string{"cfg"}, Returns: []string{"error"}})
@@ -0,0 +1,139 @@
+// Copyright (c) 2024, Cogent Core. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package enumgen
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"go/types"
+	"log/slog"
+	"math/big"
+	"slices"
+	"sort"
+)
+
+// The levels at which the problems found by [Generator.ValidateValues]
+// are reported, as set by [Config.Duplicates], [Config.Gaps],
+// and [Config.Overflow].
+const (
+	// LevelIgnore does not report problems.
+	LevelIgnore = "ignore"
+
+	// LevelWarn logs problems as warnings with [slog.Warn].
+	LevelWarn = "warn"
+
+	// LevelError reports problems as errors, which stop generation.
+	LevelError = "error"
+)
+
+// ValidateValues checks the given values of the given type, before they are
+// sorted and deduplicated with [SortValues], for duplicate values, gaps between
+// values, and values that overflow the type, reporting any problems at the
+// levels specified by [Config.Duplicates], [Config.Gaps], and [Config.Overflow].
+// An empty level is the default level of that check: [LevelWarn] for duplicates,
+// [LevelIgnore] for gaps, and [LevelError] for overflow. It returns an error for
+// any problems at [LevelError], and for invalid levels.
+func (g *Generator) ValidateValues(values []Value, typ *Type) error {
+	c := typ.Config
+	dupLevel := cmp.Or(c.Duplicates, LevelWarn)
+	gapLevel := cmp.Or(c.Gaps, LevelIgnore)
+	overLevel := cmp.Or(c.Overflow, LevelError)
+	for _, lv := range []struct{ name, level string }{{"Duplicates", dupLevel}, {"Gaps", gapLevel}, {"Overflow", overLevel}} {
+		if !slices.Contains([]string{LevelIgnore, LevelWarn, LevelError}, lv.level) {
+			return fmt.Errorf("invalid %s level %q for type %s; must be %q, %q, or %q", lv.name, lv.level, typ.Name, LevelIgnore, LevelWarn, LevelError)
+		}
+	}
+	var errs []error
+	report := func(level, format string, a ...any) {
+		switch level {
+		case LevelWarn:
+			slog.Warn("enumgen: " + fmt.Sprintf(format, a...))
+		case LevelError:
+			errs = append(errs, fmt.Errorf(format, a...))
+		}
+	}
+
+	seen := map[int64]*Value{}
+	for i := range values {
+		v := &values[i]
+		if o, has := seen[v.Value]; has {
+			report(dupLevel, "%s has the same value %s as %s of type %s, so it is ignored; use an enums:alias directive on %s to accept its name in SetString", v.OriginalName, v.Str, o.OriginalName, typ.Name, o.OriginalName)
+			continue
+		}
+		seen[v.Value] = v
+	}
+
+	sorted := slices.Clone(values)
+	sort.Stable(ByValue(sorted))
+	gaps := 0
+	var first [2]*Value
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].Value-sorted[i-1].Value > 1 {
+			if gaps == 0 {
+				first = [2]*Value{&sorted[i-1], &sorted[i]}
+			}
+			gaps++
+		}
+	}
+	if gaps > 0 {
+		report(gapLevel, "type %s has %d gap(s) between its values, starting between %s (%s) and %s (%s), so not every value below %sN is valid", typ.Name, gaps, first[0].OriginalName, first[0].Str, first[1].OriginalName, first[1].Str, typ.Name)
+	}
+
+	lo, hi := g.valueRange(typ)
+	if lo == nil {
+		return errors.Join(errs...)
+	}
+	mx := new(big.Int).Set(lo)
+	for i := range values {
+		v := &values[i]
+		bv, _ := new(big.Int).SetString(v.Str, 10)
+		if bv.Cmp(lo) < 0 || bv.Cmp(hi) > 0 {
+			if typ.IsBitFlag {
+				report(overLevel, "bit index %s = %s of bit flag type %s is outside of the range [%s, %s] of bits in the type", v.OriginalName, v.Str, typ.Name, lo, hi)
+			} else {
+				report(overLevel, "value %s = %s of type %s is outside of the range [%s, %s] of the type", v.OriginalName, v.Str, typ.Name, lo, hi)
+			}
+		}
+		if bv.Cmp(mx) > 0 {
+			mx = bv
+		}
+	}
+	if !typ.IsBitFlag && mx.Cmp(hi) == 0 {
+		report(overLevel, "the highest value of type %s plus one, %sN, is outside of the range [%s, %s] of the type", typ.Name, typ.Name, lo, hi)
+	}
+	return errors.Join(errs...)
+}
+
+// valueRange returns the inclusive range of valid values of the given type:
+// the bit indexes of a bit flag type, and otherwise the range of its
+// underlying integer type. It returns nil if the range is unknown.
+func (g *Generator) valueRange(typ *Type) (lo, hi *big.Int) {
+	if typ.IsBitFlag {
+		if typ.Words > 0 {
+			return big.NewInt(0), big.NewInt(64*typ.Words - 1)
+		}
+		return big.NewInt(0), big.NewInt(63)
+	}
+	obj := g.Pkg.TypesInfo.Defs[typ.Type.Name]
+	if obj == nil {
+		return nil, nil
+	}
+	b, ok := obj.Type().Underlying().(*types.Basic)
+	if !ok {
+		return nil, nil
+	}
+	sizes := g.Pkg.TypesSizes
+	if sizes == nil {
+		sizes = types.SizesFor("gc", "amd64")
+	}
+	bits := uint(8 * sizes.Sizeof(b))
+	one := big.NewInt(1)
+	if b.Info()&types.IsUnsigned != 0 {
+		return big.NewInt(0), new(big.Int).Sub(new(big.Int).Lsh(one, bits), one)
+	}
+	hi = new(big.Int).Sub(new(big.Int).Lsh(one, bits-1), one)
+	return new(big.Int).Neg(new(big.Int).Add(hi, one)), hi
+}
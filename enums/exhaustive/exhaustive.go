@@ -0,0 +1,238 @@
+// Copyright (c) 2024, Cogent Core. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package exhaustive provides an analyzer that reports switch statements
+// over enum types and package-level maps indexed by enum types that are
+// missing some of the values of the enum type.
+package exhaustive
+
+import (
+	"cmp"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Doc is the documentation of [Analyzer].
+const Doc = `check for missing enum values in switch statements and maps
+
+The exhaustive analyzer reports switch statements over enum types
+labeled with an //enums:enum directive that do not have a case for
+every value of the type and do not have a default case, and
+package-level variables with a map literal indexed by an enum type
+that do not have an entry for every value of the type.
+
+The values of an enum type are the constants of the type declared in
+its package, excluding the N constant generated by enumgen, and only
+including exported constants for types in other packages. Constants
+with the same value (aliases) count as one value. Switch statements
+and maps with a case or key that is not a constant are not checked.
+
+A switch statement or variable declaration that intentionally does not
+handle all values can be marked with an //enums:nonexhaustive comment
+directive on the line before it or at the end of its first line.`
+
+// Analyzer is the exhaustive analyzer.
+var Analyzer = &analysis.Analyzer{
+	Name:      "exhaustive",
+	Doc:       Doc,
+	Run:       run,
+	FactTypes: []analysis.Fact{new(isEnum)},
+}
+
+// isEnum is a fact that a type is an enum type
+// labeled with an //enums:enum directive.
+type isEnum struct{}
+
+func (*isEnum) AFact() {}
+
+func (*isEnum) String() string { return "enum" }
+
+func run(pass *analysis.Pass) (any, error) {
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				ts := spec.(*ast.TypeSpec)
+				if hasDirective(ts.Comment, "enum") {
+					pass.ExportObjectFact(pass.TypesInfo.Defs[ts.Name], new(isEnum))
+				}
+			}
+		}
+	}
+
+	for _, file := range pass.Files {
+		if ast.IsGenerated(file) {
+			continue
+		}
+		for _, decl := range file.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.VAR || ignored(pass, file, gd.Pos()) {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				vs := spec.(*ast.ValueSpec)
+				if ignored(pass, file, vs.Pos()) {
+					continue
+				}
+				for _, v := range vs.Values {
+					if lit, ok := ast.Unparen(v).(*ast.CompositeLit); ok {
+						checkMap(pass, lit)
+					}
+				}
+			}
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			if sw, ok := n.(*ast.SwitchStmt); ok && !ignored(pass, file, sw.Pos()) {
+				checkSwitch(pass, sw)
+			}
+			return true
+		})
+	}
+	return nil, nil
+}
+
+// checkSwitch reports the given switch statement if its tag is
+// an enum type and it does not have a case for every value.
+func checkSwitch(pass *analysis.Pass, sw *ast.SwitchStmt) {
+	if sw.Tag == nil {
+		return
+	}
+	named := enumType(pass, pass.TypesInfo.TypeOf(sw.Tag))
+	if named == nil {
+		return
+	}
+	covered := map[string]bool{}
+	for _, stmt := range sw.Body.List {
+		cc := stmt.(*ast.CaseClause)
+		if cc.List == nil { // default
+			return
+		}
+		for _, e := range cc.List {
+			val := pass.TypesInfo.Types[e].Value
+			if val == nil {
+				return
+			}
+			covered[val.ExactString()] = true
+		}
+	}
+	if missing := missingValues(pass, named, covered); len(missing) > 0 {
+		pass.Reportf(sw.Pos(), "missing cases in switch of type %s: %s", typeName(pass, named), strings.Join(missing, ", "))
+	}
+}
+
+// checkMap reports the given composite literal if it is a map
+// indexed by an enum type that does not have an entry for every value.
+func checkMap(pass *analysis.Pass, lit *ast.CompositeLit) {
+	mt, ok := pass.TypesInfo.TypeOf(lit).Underlying().(*types.Map)
+	if !ok {
+		return
+	}
+	named := enumType(pass, mt.Key())
+	if named == nil {
+		return
+	}
+	covered := map[string]bool{}
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			return
+		}
+		val := pass.TypesInfo.Types[kv.Key].Value
+		if val == nil {
+			return
+		}
+		covered[val.ExactString()] = true
+	}
+	if missing := missingValues(pass, named, covered); len(missing) > 0 {
+		pass.Reportf(lit.Pos(), "missing keys in map of type %s: %s", typeName(pass, named), strings.Join(missing, ", "))
+	}
+}
+
+// enumType returns the given type as a named enum type,
+// or nil if it is not an enum type.
+func enumType(pass *analysis.Pass, typ types.Type) *types.Named {
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok || !pass.ImportObjectFact(named.Obj(), new(isEnum)) {
+		return nil
+	}
+	return named
+}
+
+// missingValues returns the names of the values of the given enum type
+// that are not in the given set of covered constant values, in the order
+// in which they are declared, using the first name of any aliases.
+func missingValues(pass *analysis.Pass, named *types.Named, covered map[string]bool) []string {
+	obj := named.Obj()
+	scope := obj.Pkg().Scope()
+	var consts []*types.Const
+	for _, nm := range scope.Names() {
+		c, ok := scope.Lookup(nm).(*types.Const)
+		if !ok || !types.Identical(c.Type(), named) || nm == obj.Name()+"N" {
+			continue
+		}
+		if obj.Pkg() != pass.Pkg && !c.Exported() {
+			continue
+		}
+		consts = append(consts, c)
+	}
+	slices.SortFunc(consts, func(a, b *types.Const) int { return cmp.Compare(a.Pos(), b.Pos()) })
+	var missing []string
+	for _, c := range consts {
+		key := c.Val().ExactString()
+		if covered[key] {
+			continue
+		}
+		covered[key] = true // only report the first alias
+		missing = append(missing, c.Name())
+	}
+	return missing
+}
+
+// typeName returns the name of the given type, qualified
+// by its package name if it is in another package.
+func typeName(pass *analysis.Pass, named *types.Named) string {
+	return types.TypeString(named, func(p *types.Package) string {
+		if p == pass.Pkg {
+			return ""
+		}
+		return p.Name()
+	})
+}
+
+// hasDirective returns whether the given comment group
+// has an enums comment directive with the given name.
+func hasDirective(cg *ast.CommentGroup, name string) bool {
+	if cg == nil {
+		return false
+	}
+	for _, c := range cg.List {
+		dir, _, _ := strings.Cut(c.Text, " ")
+		if dir == "//enums:"+name {
+			return true
+		}
+	}
+	return false
+}
+
+// ignored returns whether the node at the given position in the given file
+// is marked with an //enums:nonexhaustive directive, in a comment
+// on the line before it or at the end of its first line.
+func ignored(pass *analysis.Pass, file *ast.File, pos token.Pos) bool {
+	line := pass.Fset.Position(pos).Line
+	for _, cg := range file.Comments {
+		cl := pass.Fset.Position(cg.End()).Line
+		if (cl == line-1 || cl == line) && hasDirective(cg, "nonexhaustive") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package a
+
+type Fruits int32 //enums:enum -accept-lower // want Fruits:"enum"
+
+const (
+	Apple Fruits = iota
+	Orange
+	Peach
+	lemon
+
+	Banana = Orange // alias
+)
+
+const FruitsN Fruits = 4
+
+// Flags is not checked, as it is a bit flag type.
+type Flags int64 //enums:bitflag
+
+const (
+	Enabled Flags = iota
+	Focused
+)
+
+// Ints is not checked, as it is not an enum type.
+type Ints int32
+
+const (
+	One Ints = iota
+	Two
+)
+
+func switches(f Fruits, fl Flags, i Ints) {
+	switch f { // want `missing cases in switch of type Fruits: Peach, lemon`
+	case Apple, Orange:
+	}
+	switch f {
+	case Apple, Banana, Peach, lemon:
+	}
+	switch f {
+	case Apple:
+	default:
+	}
+	switch f {
+	case Apple, Fruits(i):
+	}
+	//enums:nonexhaustive
+	switch f {
+	case Apple:
+	}
+	switch f { //enums:nonexhaustive
+	case Apple:
+	}
+	switch fl {
+	case Enabled:
+	}
+	switch i {
+	case One:
+	}
+	switch {
+	case f == Apple:
+	}
+}
+
+var FruitColors = map[Fruits]string{ // want `missing keys in map of type Fruits: Orange`
+	Apple: "red",
+	Peach: "orange",
+	lemon: "yellow",
+}
+
+var (
+	FruitSizes = map[Fruits]int{Apple: 1, Orange: 1, Peach: 2, lemon: 1}
+
+	//enums:nonexhaustive
+	FruitPits = map[Fruits]bool{Peach: true}
+)
+
+// FlagNames is not checked, as it is indexed by a bit flag type.
+var FlagNames = map[Flags]string{Enabled: "enabled"}
@@ -0,0 +1,19 @@
+package b
+
+import "a"
+
+func switches(f a.Fruits) int {
+	switch f { // want `missing cases in switch of type a.Fruits: Peach`
+	case a.Apple, a.Orange:
+		return 1
+	}
+	switch f {
+	case a.Apple, a.Banana, a.Peach:
+		return 2
+	}
+	return 0
+}
+
+var names = map[a.Fruits]string{ // want `missing keys in map of type a.Fruits: Apple, Orange`
+	a.Peach: "peach",
+}
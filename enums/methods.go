@@ -7,7 +7,9 @@ package enums
 import (
 	"errors"
 	"fmt"
+	"iter"
 	"log/slog"
+	"math/bits"
 	"strconv"
 	"strings"
 	"sync/atomic"
@@ -300,6 +302,59 @@ func HasAnyFlags(i *int64, f ...BitFlag) bool {
 	return atomic.LoadInt64(i)&mask != 0
 }
 
+// HasAllFlags returns whether this bit flag value has all of the given bit flags set.
+func HasAllFlags(i *int64, f ...BitFlag) bool {
+	var mask int64
+	for _, v := range f {
+		mask |= 1 << v.Int64()
+	}
+	return atomic.LoadInt64(i)&mask == mask
+}
+
+// Union returns the union of the given bit flag values,
+// which has the bit flags set in any of them.
+func Union[T BitFlagConstraint](i T, f ...T) T {
+	for _, v := range f {
+		i |= v
+	}
+	return i
+}
+
+// Intersect returns the intersection of the given bit flag values,
+// which has the bit flags set in all of them.
+func Intersect[T BitFlagConstraint](i T, f ...T) T {
+	for _, v := range f {
+		i &= v
+	}
+	return i
+}
+
+// Difference returns the bit flags set in the given bit flag value
+// that are not set in the other given bit flag value.
+func Difference[T BitFlagConstraint](i, f T) T {
+	return i &^ f
+}
+
+// Count returns the number of bit flags set in the given bit flag value.
+func Count[T BitFlagConstraint](i T) int {
+	return bits.OnesCount64(uint64(i))
+}
+
+// Flags returns an iterator over the bit index values of the bit flags
+// set in the given bit flag value, in increasing order.
+func Flags[T BitFlagConstraint](i T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		u := uint64(i)
+		for u != 0 {
+			b := bits.TrailingZeros64(u)
+			if !yield(T(b)) {
+				return
+			}
+			u &^= 1 << b
+		}
+	}
+}
+
 // SetFlag sets the value of the given flags in these flags to the given value.
 func SetFlag(i *int64, on bool, f ...BitFlag) {
 	var mask int64
@@ -316,6 +371,63 @@ func SetFlag(i *int64, on bool, f ...BitFlag) {
 	}
 }
 
+// HasFlagWide returns whether this array-backed bit flag value,
+// as a slice of its 64 bit words, has the given bit flag set.
+func HasFlagWide(i []uint64, f BitFlag) bool {
+	b := f.Int64()
+	return atomic.LoadUint64(&i[b/64])&(1<<uint64(b%64)) != 0
+}
+
+// SetFlagWide sets the value of the given flags in this array-backed
+// bit flag value, as a slice of its 64 bit words, to the given value.
+func SetFlagWide(i []uint64, on bool, f ...BitFlag) {
+	for _, v := range f {
+		b := v.Int64()
+		w := &i[b/64]
+		mask := uint64(1) << uint64(b%64)
+		in := atomic.LoadUint64(w)
+		if on {
+			in |= mask
+		} else {
+			in &^= mask
+		}
+		atomic.StoreUint64(w, in)
+	}
+}
+
+// BitFlagStringWide returns the string representation of the given
+// array-backed bit flag value, as a slice of its 64 bit words,
+// with the given bit index values available.
+func BitFlagStringWide[T BitFlagConstraint](i []uint64, values []T) string {
+	str := ""
+	for _, ie := range values {
+		if HasFlagWide(i, ie) {
+			ies := ie.BitIndexString()
+			if str == "" {
+				str = ies
+			} else {
+				str += "|" + ies
+			}
+		}
+	}
+	return str
+}
+
+// WarnDeprecated logs a warning with [slog.Warn] if the given string,
+// or any of its | separated bit flag names, is in the given set of names
+// of deprecated values of the enum type with the given name.
+func WarnDeprecated(s string, deprecated map[string]bool, typeName string) {
+	if deprecated[s] {
+		slog.Warn("deprecated enum value", "type", typeName, "value", s)
+		return
+	}
+	for _, flag := range strings.Split(s, "|") {
+		if deprecated[flag] {
+			slog.Warn("deprecated enum value", "type", typeName, "value", flag)
+		}
+	}
+}
+
 // UnmarshalText loads the enum from the given text.
 // It logs any error instead of returning it to prevent
 // one modified enum from tanking an entire object loading operation.
@@ -326,6 +438,21 @@ func UnmarshalText[T EnumSetter](i T, text []byte, typeName string) error {
 	return nil
 }
 
+// UnmarshalYAML loads the enum from the string decoded by the given
+// unmarshal function, which is passed to UnmarshalYAML methods by
+// gopkg.in/yaml and compatible packages. It logs any error in the
+// string instead of returning it, as in [UnmarshalText].
+func UnmarshalYAML[T EnumSetter](i T, unmarshal func(any) error, typeName string) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	if err := i.SetString(s); err != nil {
+		slog.Error(typeName+".UnmarshalYAML", "err", err)
+	}
+	return nil
+}
+
 // Scan loads the enum from the given SQL scanner value.
 func Scan[T EnumSetter](i T, value any, typeName string) error {
 	if value == nil {
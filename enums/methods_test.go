@@ -265,3 +265,50 @@ func TestUnmarshal(t *testing.T) {
 	assert.Error(t, Scan(&i, 78, "Fruits"))
 	assert.Equal(t, enum(4), i)
 }
+
+func TestFlagWide(t *testing.T) {
+	var flags [2]uint64
+	SetFlagWide(flags[:], true, enum(3), enum(64), enum(127))
+	assert.Equal(t, [2]uint64{1 << 3, 1<<0 | 1<<63}, flags)
+	assert.True(t, HasFlagWide(flags[:], enum(64)))
+	assert.False(t, HasFlagWide(flags[:], enum(5)))
+	assert.Equal(t, "bitIndexString", BitFlagStringWide(flags[:], []enum{1, 3, 5}))
+
+	SetFlagWide(flags[:], false, enum(64), enum(3))
+	assert.Equal(t, [2]uint64{0, 1 << 63}, flags)
+	assert.False(t, HasFlagWide(flags[:], enum(64)))
+	assert.True(t, HasFlagWide(flags[:], enum(127)))
+}
+
+func TestSetAlgebra(t *testing.T) {
+	a := enum(1<<1 | 1<<3 | 1<<5)
+	b := enum(1<<3 | 1<<4)
+	assert.Equal(t, enum(1<<1|1<<3|1<<4|1<<5), Union(a, b))
+	assert.Equal(t, enum(1<<1|1<<3|1<<4|1<<5|1), Union(a, b, 1))
+	assert.Equal(t, a, Union(a))
+	assert.Equal(t, enum(1<<3), Intersect(a, b))
+	assert.Equal(t, enum(0), Intersect(a, b, 1<<5))
+	assert.Equal(t, enum(1<<1|1<<5), Difference(a, b))
+	assert.Equal(t, 3, Count(a))
+	assert.Equal(t, 0, Count(enum(0)))
+	assert.Equal(t, 64, Count(enum(-1)))
+
+	var idxs []enum
+	for f := range Flags(a) {
+		idxs = append(idxs, f)
+	}
+	assert.Equal(t, []enum{1, 3, 5}, idxs)
+	for f := range Flags(enum(-1 << 63)) {
+		assert.Equal(t, enum(63), f)
+	}
+	for f := range Flags(a) {
+		assert.Equal(t, enum(1), f)
+		break
+	}
+
+	i := int64(a)
+	assert.True(t, HasAllFlags(&i, enum(1), enum(5)))
+	assert.False(t, HasAllFlags(&i, enum(1), enum(4)))
+	assert.True(t, HasAllFlags(&i))
+	assert.True(t, HasAnyFlags(&i, enum(1), enum(4)))
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2024, Cogent Core. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package enums
+
+import "fmt"
+
+// Type contains the runtime information about an enum type
+// registered with [Register], which enumgen does for each
+// enum type it generates methods for.
+type Type struct {
+
+	// Name is the name of the type, qualified by the package name
+	// (not the full package path), e.g., "vgpu.VarRoles".
+	Name string
+
+	// IsBitFlag is whether the type is a bit flag type.
+	IsBitFlag bool
+
+	// newValue returns a pointer to a new zero value of the type.
+	newValue func() EnumSetter
+
+	// value returns the value pointed to by a pointer from newValue.
+	value func(EnumSetter) Enum
+}
+
+// New returns a pointer to a new zero value of the type.
+func (t *Type) New() EnumSetter {
+	return t.newValue()
+}
+
+// Values returns all possible values of the type, including those of
+// any enum type that it extends. Each value has a Desc description.
+// For bit flag types, these are the bit index values.
+func (t *Type) Values() []Enum {
+	return t.newValue().Values()
+}
+
+// Parse returns the value of the type with the given string representation,
+// and an error if the string is invalid.
+func (t *Type) Parse(s string) (Enum, error) {
+	p := t.newValue()
+	if err := p.SetString(s); err != nil {
+		return nil, err
+	}
+	return t.value(p), nil
+}
+
+// registry is the registry of enum types, keyed by [Type.Name].
+// It is only modified during package initialization.
+var registry = map[string]*Type{}
+
+// Register adds the enum type T with the given package qualified name
+// (e.g., "vgpu.VarRoles") to the registry, replacing any existing type
+// with the same name, and returns its [Type]. It is typically called by
+// enumgen generated code in a package-level variable initializer.
+func Register[T any, PT interface {
+	*T
+	EnumSetter
+}](name string) *Type {
+	t := &Type{Name: name}
+	t.newValue = func() EnumSetter { return PT(new(T)) }
+	t.value = func(p EnumSetter) Enum { return any(*p.(PT)).(Enum) }
+	_, t.IsBitFlag = t.newValue().(BitFlagSetter)
+	registry[name] = t
+	return t
+}
+
+// TypeByName returns the registered enum type with the given package
+// qualified name (e.g., "vgpu.VarRoles"), or nil if there is none.
+func TypeByName(name string) *Type {
+	return registry[name]
+}
+
+// Parse returns the value of the registered enum type with the given
+// package qualified name (e.g., "vgpu.VarRoles") with the given string
+// representation, and an error if the type is not registered or the
+// string is invalid.
+func Parse(typeName, s string) (Enum, error) {
+	t := TypeByName(typeName)
+	if t == nil {
+		return nil, fmt.Errorf("enums.Parse: enum type %q not found", typeName)
+	}
+	return t.Parse(s)
+}
@@ -0,0 +1,28 @@
+// Copyright (c) 2024, Cogent Core. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package enums
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegister(t *testing.T) {
+	typ := Register[enum]("enums.enum")
+	assert.Same(t, typ, TypeByName("enums.enum"))
+	assert.True(t, typ.IsBitFlag)
+	_, ok := typ.New().(*enum)
+	assert.True(t, ok)
+
+	v, err := Parse("enums.enum", "Orange")
+	assert.NoError(t, err)
+	assert.Equal(t, enum(7), v)
+	_, err = Parse("enums.enum", "Apple")
+	assert.Error(t, err)
+	_, err = Parse("enums.missing", "Orange")
+	assert.Error(t, err)
+	assert.Nil(t, TypeByName("enums.missing"))
+}
@@ -9,30 +9,49 @@ and total size modulus checking of struct types to ensure HLSL
 
 Checks that struct sizes are an even multiple of 16 bytes
 (4 float32's), fields are 32 bit types: [U]Int32, Float32,
-and that fields that are other struct types are aligned
-at even 16 byte multiples.
+or the other types allowed by the [layout.Policy] (uint64, float64
+and slbool.Bool), and that fields that are other struct types
+are aligned at even 16 byte multiples.  Each violation is
+reported with a suggested fix, e.g., replace bool with slbool.Bool,
+or insert 2 padding float32 before field X, in a stable order.
+
+Fixed-size array fields ([N]T) of these types are also supported,
+and their element stride is checked against the std430 array
+stride rules used for storage buffers.
+
+Structs that are used in uniform buffers are additionally checked
+against the std140 layout rules, where the array stride of all
+element types is rounded up to 16 bytes.  Violations for each
+set of [layout.Layouts] rules are reported separately.
 */
 package alignsl
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"go/types"
+	"maps"
+	"slices"
 	"strings"
 
+	"github.com/tomas-mraz/vgpu/gosl/alignsl/layout"
 	"golang.org/x/tools/go/packages"
 )
 
 // Context for given package run
 type Context struct {
+	Layout  layout.Layouts           // layout rules to check against
+	Policy  layout.Policy            // field types allowed
 	Sizes   types.Sizes              // from package
 	Structs map[*types.Struct]string // structs that have been processed already -- value is name
 	Stack   map[*types.Struct]string // structs to process in a second pass -- structs encountered during processing of other structs
 	Errs    []string                 // accumulating list of error strings -- empty if all good
 }
 
+// NewContext returns a new context with given sizes and the [layout.DefaultPolicy].
 func NewContext(sz types.Sizes) *Context {
-	cx := &Context{Sizes: sz}
+	cx := &Context{Sizes: sz, Policy: layout.DefaultPolicy()}
 	cx.Structs = make(map[*types.Struct]string)
 	cx.Stack = make(map[*types.Struct]string)
 	return cx
@@ -54,12 +73,9 @@ func (cx *Context) AddError(ers string, hasErr bool, stName string) bool {
 	return true
 }
 
-func TypeName(tp types.Type) string {
-	switch x := tp.(type) {
-	case *types.Named:
-		return x.Obj().Name()
-	}
-	return tp.String()
+// AddFix adds a suggested fix for the last error added.
+func (cx *Context) AddFix(fix string) {
+	cx.Errs = append(cx.Errs, "        fix: "+fix)
 }
 
 // CheckStruct is the primary checker -- returns hasErr = true if there
@@ -80,17 +96,24 @@ func CheckStruct(cx *Context, st *types.Struct, stName string) bool {
 		flds = append(flds, fl)
 		ft := fl.Type()
 		ut := ft.Underlying()
-		if bt, isBasic := ut.(*types.Basic); isBasic {
-			kind := bt.Kind()
-			if !(kind == types.Uint32 || kind == types.Int32 || kind == types.Float32 || kind == types.Uint64) {
-				hasErr = cx.AddError(fmt.Sprintf("    %s:  basic type != [U]Int32 or Float32: %s", fl.Name(), bt.String()), hasErr, stName)
+		switch x := ut.(type) {
+		case *types.Basic:
+			tn, _ := layout.BasicName(ft)
+			if ok, fix := cx.Policy.Check(tn); !ok {
+				hasErr = cx.AddError(fmt.Sprintf("    %s:  basic type not allowed: %s", fl.Name(), tn), hasErr, stName)
+				cx.AddFix(fix)
 			}
-		} else {
-			if sst, is := ut.(*types.Struct); is {
-				cx.Stack[sst] = TypeName(ft)
-			} else {
-				hasErr = cx.AddError(fmt.Sprintf("    %s:  unsupported type: %s", fl.Name(), ft.String()), hasErr, stName)
+		case *types.Struct:
+			if _, isVec := layout.VectorAlign(ft); !isVec {
+				cx.Stack[x] = layout.TypeName(ft)
 			}
+		case *types.Array:
+			for _, ers := range CheckArray(cx, x, fl.Name()) {
+				hasErr = cx.AddError(ers, hasErr, stName)
+			}
+		default:
+			hasErr = cx.AddError(fmt.Sprintf("    %s:  unsupported type: %s", fl.Name(), ft.String()), hasErr, stName)
+			cx.AddFix("use an allowed basic type, a struct, or a fixed-size array of these")
 		}
 	}
 	offs := cx.Sizes.Offsetsof(flds)
@@ -100,17 +123,29 @@ func CheckStruct(cx *Context, st *types.Struct, stName string) bool {
 	if mod != 0 {
 		needs := 4 - (mod / 4)
 		hasErr = cx.AddError(fmt.Sprintf("    total size: %d not even multiple of 16 -- needs %d extra 32bit padding fields", totsz, needs), hasErr, stName)
+		cx.AddFix(fmt.Sprintf("add %d padding float32 after field %s", needs, flds[nf-1].Name()))
 	}
 
-	// check that struct starts at mod 16 byte offset
+	// check that struct starts at mod 16 byte offset,
+	// and vector types at their alignment
 	for i, fl := range flds {
 		ft := fl.Type()
 		ut := ft.Underlying()
+		if at, is := ut.(*types.Array); is {
+			ft, _ = layout.ArrayElem(at)
+			ut = ft.Underlying()
+		}
+		if al, isVec := layout.VectorAlign(ft); isVec {
+			for _, ers := range layout.VectorErrors(fl.Name(), layout.TypeName(ft), offs[i], al, cx.Layout) {
+				hasErr = cx.AddError(ers, hasErr, stName)
+			}
+			continue
+		}
 		if _, is := ut.(*types.Struct); is {
 			off := offs[i]
 			if off%16 != 0 {
-
-				hasErr = cx.AddError(fmt.Sprintf("    %s:  struct type: %s is not at mod-16 byte offset: %d", fl.Name(), TypeName(ft), off), hasErr, stName)
+				hasErr = cx.AddError(fmt.Sprintf("    %s:  struct type: %s is not at mod-16 byte offset: %d", fl.Name(), layout.TypeName(ft), off), hasErr, stName)
+				cx.AddFix(layout.PadFix(off, fl.Name()))
 			}
 		}
 	}
@@ -118,36 +153,166 @@ func CheckStruct(cx *Context, st *types.Struct, stName string) bool {
 	return hasErr
 }
 
+// ArrayStride returns the array stride for the given element type
+// under the context Layout.  For std430: the size of basic types, the
+// size of struct types rounded up to 16 bytes (the struct alignment
+// enforced here), and the full size of array types for arrays of arrays.
+// For std140, all strides are rounded up to 16 bytes.
+func ArrayStride(cx *Context, et types.Type) int64 {
+	var sz int64
+	if al, isVec := layout.VectorAlign(et); isVec {
+		return layout.VectorStride(cx.Sizes.Sizeof(et), al, cx.Layout)
+	}
+	switch x := et.Underlying().(type) {
+	case *types.Struct:
+		sz = cx.Sizes.Sizeof(x)
+		return ((sz + 15) / 16) * 16
+	case *types.Array:
+		sz = x.Len() * ArrayStride(cx, x.Elem())
+	default:
+		sz = cx.Sizes.Sizeof(et)
+	}
+	if cx.Layout == layout.Std140 {
+		return ((sz + 15) / 16) * 16
+	}
+	return sz
+}
+
+// CheckArray checks a fixed-size array field: the element type must
+// be an allowed basic type or a struct, and the Go element stride must
+// match the array stride of the context Layout.
+// Struct element types are added to the Stack for checking.
+// Returns a list of error strings, each followed by a suggested fix,
+// empty if all good.
+func CheckArray(cx *Context, at *types.Array, flName string) []string {
+	var errs []string
+	et, n := layout.ArrayElem(at)
+	switch x := et.Underlying().(type) {
+	case *types.Basic:
+		tn, _ := layout.BasicName(et)
+		if ok, fix := cx.Policy.Check(tn); !ok {
+			errs = append(errs, fmt.Sprintf("    %s:  array element basic type not allowed: %s", flName, tn), "        fix: "+fix)
+		}
+	case *types.Struct:
+		if _, isVec := layout.VectorAlign(et); !isVec {
+			cx.Stack[x] = layout.TypeName(et)
+		}
+	default:
+		errs = append(errs, fmt.Sprintf("    %s:  unsupported array element type: %s", flName, et.String()), "        fix: use an array of an allowed basic type or struct")
+		return errs
+	}
+	gost := cx.Sizes.Sizeof(at.Elem())
+	slst := ArrayStride(cx, at.Elem())
+	if gost != slst {
+		errs = append(errs, fmt.Sprintf("    %s:  array element stride: %d != %s stride: %d -- element type: %s size must be an even multiple of 16", flName, gost, cx.Layout, slst, layout.TypeName(et)))
+		_, isStruct := et.Underlying().(*types.Struct)
+		fix := layout.StrideFix(layout.TypeName(et), isStruct, n, gost, slst)
+		if _, isVec := layout.VectorAlign(et); isVec {
+			fix = layout.VectorStrideFix(layout.TypeName(et))
+		}
+		errs = append(errs, "        fix: "+fix)
+	}
+	return errs
+}
+
+// Options are the options for [CheckPackageOptions].
+type Options struct {
+
+	// Policy determines the allowed field types.
+	Policy layout.Policy
+
+	// Layouts has the additional layout rules to check struct types
+	// against, by type name: all struct types are checked against the
+	// std430 rules, and those with a [layout.Std140] value are additionally checked
+	// against the std140 rules, along with the struct types they contain.
+	Layouts map[string]layout.Layouts
+}
+
+// DefaultOptions returns the default [Options], with the [layout.DefaultPolicy]
+// and no additional layouts.
+func DefaultOptions() Options {
+	return Options{Policy: layout.DefaultPolicy()}
+}
+
 // CheckPackage is main entry point for checking a package
 // returns error string if any errors found.
+// It uses the [DefaultOptions]: see [CheckPackageOptions].
 func CheckPackage(pkg *packages.Package) error {
+	return CheckPackageOptions(pkg, DefaultOptions())
+}
+
+// CheckPackageOptions checks a package with the given options,
+// returning an error string if any errors found.
+// The errors for the std430 and std140 rules are reported separately.
+func CheckPackageOptions(pkg *packages.Package, opts Options) error {
+	pol := opts.Policy
+	layouts := opts.Layouts
 	cx := NewContext(pkg.TypesSizes)
+	cx.Policy = pol
 	sc := pkg.Types.Scope()
 	hasErr := CheckScope(cx, sc, 0)
 	er := CheckStack(cx)
+	var str string
 	if hasErr || er {
-		str := `
+		str = `
 WARNING: in struct type alignment checking:
     Checks that struct sizes are an even multiple of 16 bytes (4 float32's),
-    and fields are 32 bit types: [U]Int32, Float32 or other struct,
+    and fields are allowed basic types: ` + pol.String() + `, or other struct, or fixed-size arrays of these,
     and that fields that are other struct types are aligned at even 16 byte multiples.
-    List of errors found follow below, by struct type name:
+    List of errors found follow below, by struct type name, each with a suggested fix:
 ` + strings.Join(cx.Errs, "\n")
+	}
+	ucx := NewContext(pkg.TypesSizes)
+	ucx.Policy = pol
+	ucx.Layout = layout.Std140
+	hasErr = false
+	for _, nm := range sc.Names() {
+		if layouts[nm] != layout.Std140 {
+			continue
+		}
+		nt, is := sc.Lookup(nm).Type().(*types.Named)
+		if !is {
+			continue
+		}
+		if st, is := nt.Underlying().(*types.Struct); is {
+			if CheckStruct(ucx, st, nm) {
+				hasErr = true
+			}
+		}
+	}
+	if CheckStack(ucx) {
+		hasErr = true
+	}
+	if hasErr {
+		str += `
+WARNING: in std140 uniform buffer struct type alignment checking:
+    Checks in addition that all array elements are at even 16 byte strides, for struct types
+    used in uniform buffers (ConstantBuffer, Uniform role) or marked with //gosl:layout std140.
+    List of errors found follow below, by struct type name, each with a suggested fix:
+` + strings.Join(ucx.Errs, "\n")
+	}
+	if str != "" {
 		return errors.New(str)
 	}
 	return nil
 }
 
+// CheckStack checks the structs on the Stack, in order of their names
+// so that the errors are always reported in the same order.
 func CheckStack(cx *Context) bool {
 	hasErr := false
 	for {
 		if len(cx.Stack) == 0 {
 			break
 		}
-		st := cx.Stack
+		stk := cx.Stack
 		cx.Stack = make(map[*types.Struct]string) // new stack
-		for st, nm := range st {
-			er := CheckStruct(cx, st, nm)
+		sts := slices.Collect(maps.Keys(stk))
+		slices.SortFunc(sts, func(a, b *types.Struct) int {
+			return cmp.Or(cmp.Compare(stk[a], stk[b]), cmp.Compare(a.String(), b.String()))
+		})
+		for _, st := range sts {
+			er := CheckStruct(cx, st, stk[st])
 			if er {
 				hasErr = true
 			}
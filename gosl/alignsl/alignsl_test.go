@@ -0,0 +1,70 @@
+// Copyright (c) 2024, Cogent Core. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package alignsl
+
+import (
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomas-mraz/vgpu/gosl/alignsl/layout"
+)
+
+func TestStd140(t *testing.T) {
+	f32 := types.Typ[types.Float32]
+	sz := types.SizesFor("gc", "amd64")
+	fld := func(nm string, tp types.Type) *types.Var {
+		return types.NewField(token.NoPos, nil, nm, tp, false)
+	}
+	st := types.NewStruct([]*types.Var{fld("Gains", types.NewArray(f32, 4))}, nil)
+	vst := types.NewStruct([]*types.Var{fld("V", types.NewArray(types.NewArray(f32, 4), 2))}, nil)
+
+	cx := NewContext(sz)
+	assert.False(t, CheckStruct(cx, st, "UniParams"))
+	assert.False(t, CheckStruct(cx, vst, "VecParams"))
+	assert.Empty(t, cx.Errs)
+
+	cx = NewContext(sz)
+	cx.Layout = layout.Std140
+	assert.True(t, CheckStruct(cx, st, "UniParams"))
+	assert.True(t, CheckStruct(cx, vst, "VecParams"))
+	assert.Equal(t, []string{
+		"UniParams",
+		"    Gains:  array element stride: 4 != std140 stride: 16 -- element type: float32 size must be an even multiple of 16",
+		"        fix: use [1]math32.Vector4 (4 values per element) to hold the 4 values, or use a storage buffer",
+		"VecParams",
+		"    V:  array element stride: 16 != std140 stride: 64 -- element type: float32 size must be an even multiple of 16",
+		"        fix: use [2]math32.Vector4 (4 values per element) to hold the 8 values, or use a storage buffer",
+	}, cx.Errs)
+
+	ly, err := layout.ParseLayout("std140")
+	assert.NoError(t, err)
+	assert.Equal(t, layout.Std140, ly)
+	_, err = layout.ParseLayout("std999")
+	assert.Error(t, err)
+}
+
+func TestCheckStructPolicy(t *testing.T) {
+	f64 := types.Typ[types.Float64]
+	b := types.Typ[types.Bool]
+	fld := func(nm string, tp types.Type) *types.Var {
+		return types.NewField(token.NoPos, nil, nm, tp, false)
+	}
+	st := types.NewStruct([]*types.Var{fld("On", b), fld("Val", f64), fld("Vals", types.NewArray(b, 4))}, nil)
+	cx := NewContext(types.SizesFor("gc", "amd64"))
+	assert.True(t, CheckStruct(cx, st, "BoolParams"))
+	assert.Equal(t, []string{
+		"BoolParams",
+		"    On:  basic type not allowed: bool",
+		"        fix: replace bool with slbool.Bool",
+		"    Val:  basic type not allowed: float64",
+		"        fix: replace float64 with float32",
+		"    Vals:  array element basic type not allowed: bool",
+		"        fix: replace bool with slbool.Bool",
+		"    total size: 20 not even multiple of 16 -- needs 3 extra 32bit padding fields",
+		"        fix: add 3 padding float32 after field Vals",
+	}, cx.Errs)
+}
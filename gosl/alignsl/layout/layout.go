@@ -0,0 +1,210 @@
+// Copyright (c) 2024, Cogent Core. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package layout defines the buffer memory layouts of struct types
+// and the field types that are allowed in them, and checks struct types
+// obtained via reflection against them, for the alignsl checking.
+// It does not depend on golang.org/x/tools/go/packages, so it can
+// also be used at runtime, e.g., by vgpu.VarSet.AddStructType.
+package layout
+
+import (
+	"fmt"
+	"go/types"
+)
+
+// Layouts are the buffer memory layout rule sets that structs are checked against.
+type Layouts int32
+
+const (
+	// Std430 is the layout for storage buffers (Storage role vars),
+	// and the baseline rules for all structs.
+	Std430 Layouts = iota
+
+	// Std140 is the layout for uniform buffers (Uniform role vars),
+	// which additionally requires all array element strides to be
+	// an even multiple of 16 bytes.
+	Std140
+)
+
+// String returns the name of the layout, as used in //gosl:layout
+func (ly Layouts) String() string {
+	if ly == Std140 {
+		return "std140"
+	}
+	return "std430"
+}
+
+// ParseLayout returns the layout for the given name: std140 or std430.
+func ParseLayout(s string) (Layouts, error) {
+	switch s {
+	case "std140":
+		return Std140, nil
+	case "std430":
+		return Std430, nil
+	}
+	return Std430, fmt.Errorf("alignsl: unknown layout: %q, must be std140 or std430", s)
+}
+
+// RoleLayout returns the layout for a buffer bound with the given
+// vgpu.VarRoles name: Std140 for Uniform, otherwise Std430.
+func RoleLayout(role string) Layouts {
+	if role == "Uniform" {
+		return Std140
+	}
+	return Std430
+}
+
+// StructLayout is the memory layout of a struct type,
+// as computed by the package types.Sizes.
+type StructLayout struct {
+
+	// name of the struct type
+	Name string `json:"name"`
+
+	// total size in bytes
+	Size int64 `json:"size"`
+
+	// layout of each field, in order
+	Fields []FieldLayout `json:"fields"`
+}
+
+// FieldLayout is the memory layout of a struct field.
+type FieldLayout struct {
+
+	// name of the field
+	Name string `json:"name"`
+
+	// type name of the field (for arrays, the innermost element type)
+	Type string `json:"type"`
+
+	// kind of underlying type: float32, int32, uint32, struct, etc
+	Kind string `json:"kind"`
+
+	// byte offset of the field within the struct
+	Offset int64 `json:"offset"`
+
+	// size of the field in bytes
+	Size int64 `json:"size"`
+
+	// lengths of each array dimension, outermost first, for array fields
+	ArrayDims []int64 `json:"arrayDims,omitempty"`
+
+	// byte stride between elements of the outermost array dimension,
+	// for array fields
+	ArrayStride int64 `json:"arrayStride,omitempty"`
+}
+
+// TypeKind returns the kind of the underlying type of given type:
+// the basic type name, or struct, array, etc.
+func TypeKind(tp types.Type) string {
+	switch x := tp.Underlying().(type) {
+	case *types.Basic:
+		return x.Name()
+	case *types.Struct:
+		return "struct"
+	case *types.Array:
+		return "array"
+	}
+	return tp.Underlying().String()
+}
+
+// Layout returns the [StructLayout] for given struct type with given
+// name, using the given sizes.
+func Layout(sz types.Sizes, name string, st *types.Struct) *StructLayout {
+	sl := &StructLayout{Name: name}
+	nf := st.NumFields()
+	if nf == 0 {
+		return sl
+	}
+	flds := make([]*types.Var, nf)
+	for i := 0; i < nf; i++ {
+		flds[i] = st.Field(i)
+	}
+	offs := sz.Offsetsof(flds)
+	for i, fl := range flds {
+		ft := fl.Type()
+		fly := FieldLayout{Name: fl.Name(), Type: TypeName(ft), Kind: TypeKind(ft), Offset: offs[i], Size: sz.Sizeof(ft)}
+		if at, is := ft.Underlying().(*types.Array); is {
+			et, _ := ArrayElem(at)
+			fly.Type = TypeName(et)
+			fly.Kind = TypeKind(et)
+			fly.ArrayStride = sz.Sizeof(at.Elem())
+			for {
+				fly.ArrayDims = append(fly.ArrayDims, at.Len())
+				sat, is := at.Elem().Underlying().(*types.Array)
+				if !is {
+					break
+				}
+				at = sat
+			}
+		}
+		sl.Fields = append(sl.Fields, fly)
+	}
+	sl.Size = sz.Sizeof(st)
+	return sl
+}
+
+// StructDeps returns the named struct types used in the fields of
+// given struct type, including within arrays, recursively, in order
+// of first use.
+func StructDeps(st *types.Struct) []*types.Named {
+	var deps []*types.Named
+	has := map[*types.Named]bool{}
+	var add func(st *types.Struct)
+	add = func(st *types.Struct) {
+		for i := 0; i < st.NumFields(); i++ {
+			ft := st.Field(i).Type()
+			if at, is := ft.Underlying().(*types.Array); is {
+				ft, _ = ArrayElem(at)
+			}
+			nt, is := ft.(*types.Named)
+			if !is || has[nt] {
+				continue
+			}
+			sst, is := nt.Underlying().(*types.Struct)
+			if !is {
+				continue
+			}
+			has[nt] = true
+			add(sst)
+			deps = append(deps, nt)
+		}
+	}
+	add(st)
+	return deps
+}
+
+func TypeName(tp types.Type) string {
+	switch x := tp.(type) {
+	case *types.Named:
+		return x.Obj().Name()
+	}
+	return tp.String()
+}
+
+// ArrayElem returns the innermost element type of a (possibly
+// nested) fixed-size array type, and the total number of elements.
+func ArrayElem(at *types.Array) (types.Type, int64) {
+	n := at.Len()
+	et := at.Elem()
+	for {
+		sat, is := et.Underlying().(*types.Array)
+		if !is {
+			return et, n
+		}
+		n *= sat.Len()
+		et = sat.Elem()
+	}
+}
+
+// StrideFix returns the suggested fix for an array with given innermost
+// element type name, whether that is a struct, and total number of elements,
+// where the Go element stride does not match the required layout stride.
+func StrideFix(etName string, isStruct bool, n, gost, slst int64) string {
+	if isStruct {
+		return fmt.Sprintf("add %d padding float32 at the end of struct %s", (slst-gost)/4, etName)
+	}
+	return fmt.Sprintf("use [%d]math32.Vector4 (4 values per element) to hold the %d values, or use a storage buffer", (n+3)/4, n)
+}
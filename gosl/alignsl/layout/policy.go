@@ -0,0 +1,129 @@
+// Copyright (c) 2024, Cogent Core. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package layout
+
+import (
+	"fmt"
+	"go/types"
+	"strings"
+)
+
+// SlBoolPath is the package path of the slbool package, whose Bool
+// type is the GPU-compatible replacement for bool.
+const SlBoolPath = "github.com/tomas-mraz/vgpu/gosl/slbool"
+
+// Policy specifies which field types are allowed in structs, in
+// addition to the int32, uint32 and float32 types that are always
+// allowed.  All other basic types (bool, int, int16, int64, etc)
+// are always reported, with a suggested replacement.
+type Policy struct {
+
+	// allow uint64 fields, which require 64 bit integer support in the shader
+	Uint64 bool
+
+	// allow float64 fields, which require 64 bit float support in the shader
+	Float64 bool
+
+	// allow slbool.Bool fields, the 32 bit GPU version of bool
+	SlBool bool
+}
+
+// DefaultPolicy returns the default [Policy], which allows
+// uint64 and slbool.Bool, but not float64.
+func DefaultPolicy() Policy {
+	return Policy{Uint64: true, SlBool: true}
+}
+
+// ParsePolicy returns the [DefaultPolicy] with the changes in the given
+// comma-separated list of: uint64, float64, slbool.  Each listed type is
+// allowed in addition to the default ones, and a type with a - prefix
+// (e.g., -slbool) is not allowed.
+func ParsePolicy(allow string) (Policy, error) {
+	pl := DefaultPolicy()
+	for _, tn := range strings.Split(allow, ",") {
+		tn = strings.TrimSpace(tn)
+		on := !strings.HasPrefix(tn, "-")
+		switch strings.TrimPrefix(tn, "-") {
+		case "":
+		case "uint64":
+			pl.Uint64 = on
+		case "float64":
+			pl.Float64 = on
+		case "slbool", "slbool.Bool":
+			pl.SlBool = on
+		default:
+			return pl, fmt.Errorf("alignsl: unknown type to allow: %q, must be one of: uint64, float64, slbool, optionally with a - prefix to not allow it", tn)
+		}
+	}
+	return pl, nil
+}
+
+// String returns the list of allowed types
+func (pl *Policy) String() string {
+	al := []string{"int32", "uint32", "float32"}
+	if pl.Uint64 {
+		al = append(al, "uint64")
+	}
+	if pl.Float64 {
+		al = append(al, "float64")
+	}
+	if pl.SlBool {
+		al = append(al, "slbool.Bool")
+	}
+	return strings.Join(al, ", ")
+}
+
+// Check returns true if the given basic field type name is allowed,
+// and otherwise a suggested fix.  The type name is the basic kind
+// name (e.g., bool, int16, uint64), or slbool.Bool for that type.
+func (pl *Policy) Check(tn string) (bool, string) {
+	repl := func(to string) string {
+		return fmt.Sprintf("replace %s with %s", tn, to)
+	}
+	switch tn {
+	case "int32", "uint32", "float32":
+		return true, ""
+	case "uint64":
+		return pl.Uint64, repl("uint32")
+	case "float64":
+		return pl.Float64, repl("float32")
+	case "slbool.Bool":
+		return pl.SlBool, repl("int32")
+	case "bool":
+		if pl.SlBool {
+			return false, repl("slbool.Bool")
+		}
+		return false, repl("int32")
+	case "int", "int8", "int16", "int64", "rune":
+		return false, repl("int32")
+	case "uint", "uint8", "uint16", "uintptr", "byte":
+		return false, repl("uint32")
+	}
+	return false, repl("a 32 bit type: int32, uint32 or float32")
+}
+
+// BasicName returns the name of the given basic type for [Policy.Check],
+// or false if it is not a basic type.
+func BasicName(tp types.Type) (string, bool) {
+	if nt, is := tp.(*types.Named); is {
+		if ob := nt.Obj(); ob.Pkg() != nil && ob.Pkg().Path() == SlBoolPath && ob.Name() == "Bool" {
+			return "slbool.Bool", true
+		}
+	}
+	if bt, is := tp.Underlying().(*types.Basic); is {
+		return bt.Name(), true
+	}
+	return "", false
+}
+
+// PadFix returns the suggested fix for a struct field at the given
+// offset that must be at a mod-16 byte offset.
+func PadFix(off int64, flName string) string {
+	pad := 16 - off%16
+	if pad%4 != 0 {
+		return fmt.Sprintf("insert %d padding bytes before field %s", pad, flName)
+	}
+	return fmt.Sprintf("insert %d padding float32 before field %s", pad/4, flName)
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2024, Cogent Core. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package layout
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomas-mraz/vgpu/gosl/slbool"
+)
+
+func TestPolicy(t *testing.T) {
+	type Params struct {
+		Count uint64
+		Gain  float64
+		Flag  slbool.Bool
+		Idx   int16
+		pad   int16
+		pad1  int32
+	}
+	pt := reflect.TypeOf(Params{})
+	err := CheckType(pt, Std430)
+	assert.Equal(t, `alignsl: std430 layout errors in type Params:
+Params
+    Gain:  basic type not allowed: float64
+        fix: replace float64 with float32
+    Idx:  basic type not allowed: int16
+        fix: replace int16 with int32
+    pad:  basic type not allowed: int16
+        fix: replace int16 with int32`, err.Error())
+
+	pol, err := ParsePolicy("float64")
+	assert.NoError(t, err)
+	assert.Equal(t, "int32, uint32, float32, uint64, float64, slbool.Bool", pol.String())
+	err = CheckTypePolicy(pt, Std430, pol)
+	assert.Contains(t, err.Error(), "Idx")
+	assert.NotContains(t, err.Error(), "Count")
+	assert.NotContains(t, err.Error(), "Flag")
+	assert.NotContains(t, err.Error(), "Gain")
+
+	pol, err = ParsePolicy("float64, -uint64, -slbool")
+	assert.NoError(t, err)
+	assert.Equal(t, "int32, uint32, float32, float64", pol.String())
+	err = CheckTypePolicy(pt, Std430, pol)
+	assert.Contains(t, err.Error(), "    Count:  basic type not allowed: uint64\n        fix: replace uint64 with uint32\n")
+	assert.Contains(t, err.Error(), "    Flag:  basic type not allowed: slbool.Bool\n        fix: replace slbool.Bool with int32\n")
+	assert.NotContains(t, err.Error(), "Gain")
+
+	_, err = ParsePolicy("uint64,int16")
+	assert.Error(t, err)
+	pol, err = ParsePolicy("")
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultPolicy(), pol)
+
+}
@@ -0,0 +1,215 @@
+// Copyright (c) 2024, Cogent Core. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package layout
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// CheckType checks the given struct type, obtained via reflection,
+// against the same rules as alignsl.CheckStruct, for given layout,
+// including any struct types it contains.  This can be used at
+// runtime on types that are uploaded to the GPU, e.g., by
+// vgpu.VarSet.AddStructType.  A pointer to a struct is also accepted.
+// Returns an error listing all the violations, by struct type name,
+// each with a suggested fix, or nil if all good.
+// The [DefaultPolicy] determines the allowed field types:
+// see [CheckTypePolicy] to use a different one.
+func CheckType(typ reflect.Type, layout Layouts) error {
+	return CheckTypePolicy(typ, layout, DefaultPolicy())
+}
+
+// CheckTypePolicy is [CheckType] with the given [Policy] for the
+// allowed field types.
+func CheckTypePolicy(typ reflect.Type, layout Layouts, pol Policy) error {
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return fmt.Errorf("alignsl.CheckType: type %s is not a struct", typ.String())
+	}
+	var errs []string
+	done := map[reflect.Type]bool{}
+	stack := []reflect.Type{typ}
+	for len(stack) > 0 {
+		st := stack[0]
+		stack = stack[1:]
+		if done[st] {
+			continue
+		}
+		done[st] = true
+		sterrs, sub := checkReflectStruct(st, layout, &pol)
+		if len(sterrs) > 0 {
+			errs = append(errs, st.Name())
+			errs = append(errs, sterrs...)
+		}
+		stack = append(stack, sub...)
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("alignsl: %s layout errors in type %s:\n%s", layout, typ.Name(), strings.Join(errs, "\n"))
+}
+
+// checkReflectStruct returns the errors for given struct type,
+// each followed by a suggested fix, and the struct types it contains.
+func checkReflectStruct(st reflect.Type, layout Layouts, pol *Policy) ([]string, []reflect.Type) {
+	var errs []string
+	var sub []reflect.Type
+	nf := st.NumField()
+	if nf == 0 {
+		return nil, nil
+	}
+	addErr := func(fix, format string, args ...any) {
+		errs = append(errs, fmt.Sprintf(format, args...), "        fix: "+fix)
+	}
+	for i := 0; i < nf; i++ {
+		fl := st.Field(i)
+		ft := fl.Type
+		vt := ft
+		if ft.Kind() == reflect.Array {
+			vt = ReflectArrayElem(ft)
+		}
+		if al, isVec := ReflectVectorAlign(vt); isVec {
+			errs = append(errs, VectorErrors(fl.Name, typeString(vt), int64(fl.Offset), al, layout)...)
+			if ft.Kind() == reflect.Array {
+				gost := int64(ft.Elem().Size())
+				if slst := ReflectArrayStride(ft.Elem(), layout); gost != slst {
+					addErr(VectorStrideFix(typeString(vt)), "    %s:  array element stride: %d != %s stride: %d -- element type: %s size must be an even multiple of 16", fl.Name, gost, layout, slst, typeString(vt))
+				}
+			}
+			continue
+		}
+		switch ft.Kind() {
+		case reflect.Struct:
+			sub = append(sub, ft)
+			if fl.Offset%16 != 0 {
+				addErr(PadFix(int64(fl.Offset), fl.Name), "    %s:  struct type: %s is not at mod-16 byte offset: %d", fl.Name, ft.Name(), fl.Offset)
+			}
+		case reflect.Array:
+			et := ReflectArrayElem(ft)
+			isStruct := et.Kind() == reflect.Struct
+			switch {
+			case isStruct:
+				sub = append(sub, et)
+				if fl.Offset%16 != 0 {
+					addErr(PadFix(int64(fl.Offset), fl.Name), "    %s:  struct type: %s is not at mod-16 byte offset: %d", fl.Name, et.Name(), fl.Offset)
+				}
+			case !isReflectBasic(et):
+				addErr("use an array of an allowed basic type or struct", "    %s:  unsupported array element type: %s", fl.Name, et.String())
+				continue
+			default:
+				tn := reflectBasicName(et)
+				if ok, fix := pol.Check(tn); !ok {
+					addErr(fix, "    %s:  array element basic type not allowed: %s", fl.Name, tn)
+				}
+			}
+			gost := int64(ft.Elem().Size())
+			slst := ReflectArrayStride(ft.Elem(), layout)
+			if gost != slst {
+				n := int64(ft.Size()) / int64(et.Size())
+				addErr(StrideFix(typeString(et), isStruct, n, gost, slst), "    %s:  array element stride: %d != %s stride: %d -- element type: %s size must be an even multiple of 16", fl.Name, gost, layout, slst, typeString(et))
+			}
+		default:
+			if !isReflectBasic(ft) {
+				addErr("use an allowed basic type, a struct, or a fixed-size array of these", "    %s:  unsupported type: %s", fl.Name, ft.String())
+				continue
+			}
+			tn := reflectBasicName(ft)
+			if ok, fix := pol.Check(tn); !ok {
+				addErr(fix, "    %s:  basic type not allowed: %s", fl.Name, tn)
+			}
+		}
+	}
+	totsz := int(st.Size())
+	if mod := totsz % 16; mod != 0 {
+		needs := 4 - (mod / 4)
+		addErr(fmt.Sprintf("add %d padding float32 after field %s", needs, st.Field(nf-1).Name), "    total size: %d not even multiple of 16 -- needs %d extra 32bit padding fields", totsz, needs)
+	}
+	return errs, sub
+}
+
+// isReflectBasic returns true if the given type is a basic
+// boolean or numeric type.
+func isReflectBasic(tp reflect.Type) bool {
+	kind := tp.Kind()
+	return kind >= reflect.Bool && kind <= reflect.Complex128
+}
+
+// reflectBasicName is the reflection version of [BasicName].
+func reflectBasicName(tp reflect.Type) string {
+	if tp.PkgPath() == SlBoolPath && tp.Name() == "Bool" {
+		return "slbool.Bool"
+	}
+	return tp.Kind().String()
+}
+
+// ReflectArrayElem returns the innermost element type of a (possibly
+// nested) fixed-size array type.
+func ReflectArrayElem(at reflect.Type) reflect.Type {
+	et := at.Elem()
+	for et.Kind() == reflect.Array {
+		et = et.Elem()
+	}
+	return et
+}
+
+// ReflectArrayStride is the reflection version of alignsl.ArrayStride.
+func ReflectArrayStride(et reflect.Type, layout Layouts) int64 {
+	var sz int64
+	if al, isVec := ReflectVectorAlign(et); isVec {
+		return VectorStride(int64(et.Size()), al, layout)
+	}
+	switch et.Kind() {
+	case reflect.Struct:
+		sz = int64(et.Size())
+		return ((sz + 15) / 16) * 16
+	case reflect.Array:
+		sz = int64(et.Len()) * ReflectArrayStride(et.Elem(), layout)
+	default:
+		sz = int64(et.Size())
+	}
+	if layout == Std140 {
+		return ((sz + 15) / 16) * 16
+	}
+	return sz
+}
+
+// TypeLayout returns the [StructLayout] for given struct type,
+// obtained via reflection, in the same form as [Layout].
+// A pointer to a struct is also accepted.
+func TypeLayout(typ reflect.Type) *StructLayout {
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	sl := &StructLayout{Name: typ.Name(), Size: int64(typ.Size())}
+	for i := 0; i < typ.NumField(); i++ {
+		fl := typ.Field(i)
+		ft := fl.Type
+		fly := FieldLayout{Name: fl.Name, Type: typeString(ft), Kind: ft.Kind().String(), Offset: int64(fl.Offset), Size: int64(ft.Size())}
+		if ft.Kind() == reflect.Array {
+			et := ReflectArrayElem(ft)
+			fly.Type = typeString(et)
+			fly.Kind = et.Kind().String()
+			fly.ArrayStride = int64(ft.Elem().Size())
+			for at := ft; at.Kind() == reflect.Array; at = at.Elem() {
+				fly.ArrayDims = append(fly.ArrayDims, int64(at.Len()))
+			}
+		}
+		sl.Fields = append(sl.Fields, fly)
+	}
+	return sl
+}
+
+// typeString returns the name of a named type, else its string
+// representation, as in [TypeName].
+func typeString(tp reflect.Type) string {
+	if nm := tp.Name(); nm != "" {
+		return nm
+	}
+	return tp.String()
+}
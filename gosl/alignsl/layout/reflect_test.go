@@ -0,0 +1,50 @@
+// Copyright (c) 2024, Cogent Core. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package layout
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckType(t *testing.T) {
+	type Chan struct {
+		Gbar, Erev, pad, pad1 float32
+	}
+	type GoodParams struct {
+		Tau   float32
+		Dt    float32
+		Count int32
+		pad   float32
+		Chans [2]Chan
+	}
+	type BadParams struct {
+		Tau  float32
+		On   bool
+		Chan Chan
+		Vals [3]float32
+	}
+	assert.NoError(t, CheckType(reflect.TypeOf(GoodParams{}), Std430))
+	assert.NoError(t, CheckType(reflect.TypeOf(&GoodParams{}), Std140))
+	assert.Error(t, CheckType(reflect.TypeOf(float32(0)), Std430))
+
+	err := CheckType(reflect.TypeOf(BadParams{}), Std140)
+	assert.Equal(t, `alignsl: std140 layout errors in type BadParams:
+BadParams
+    On:  basic type not allowed: bool
+        fix: replace bool with slbool.Bool
+    Chan:  struct type: Chan is not at mod-16 byte offset: 8
+        fix: insert 2 padding float32 before field Chan
+    Vals:  array element stride: 4 != std140 stride: 16 -- element type: float32 size must be an even multiple of 16
+        fix: use [1]math32.Vector4 (4 values per element) to hold the 3 values, or use a storage buffer
+    total size: 36 not even multiple of 16 -- needs 3 extra 32bit padding fields
+        fix: add 3 padding float32 after field Vals`, err.Error())
+
+	sl := TypeLayout(reflect.TypeOf(GoodParams{}))
+	assert.Equal(t, int64(48), sl.Size)
+	assert.Equal(t, FieldLayout{Name: "Chans", Type: "Chan", Kind: "struct", Offset: 16, Size: 32, ArrayDims: []int64{2}, ArrayStride: 16}, sl.Fields[4])
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2024, Cogent Core. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package layout
+
+import (
+	"fmt"
+	"go/types"
+	"reflect"
+	"strings"
+)
+
+const (
+	// SlTypePath is the package path of the sltype package,
+	// which defines the vector and matrix types.
+	SlTypePath = "github.com/tomas-mraz/vgpu/gosl/sltype"
+
+	// Math32Path is the package path of the math32 package,
+	// whose Vector types are aliased by the sltype Float types.
+	Math32Path = "cogentcore.org/core/math32"
+)
+
+// VectorTypes are the sltype and math32 vector and matrix types
+// that gosl translates into native HLSL types, with their alignment.
+// These are checked as a unit, not as a struct: 2 component vectors
+// only require an 8 byte alignment, and all others 16 bytes.
+var VectorTypes = map[string]int64{
+	"Int2":     8,
+	"Int3":     16,
+	"Int4":     16,
+	"Uint2":    8,
+	"Uint3":    16,
+	"Uint4":    16,
+	"Vector2":  8,
+	"Vector3":  16,
+	"Vector4":  16,
+	"Vector2i": 8,
+	"Vector3i": 16,
+	"Float2x2": 8,
+	"Float3x3": 16,
+	"Float4x4": 16,
+}
+
+// VectorAlign returns the alignment of the given type if it is
+// one of the [VectorTypes], and false otherwise.
+func VectorAlign(tp types.Type) (int64, bool) {
+	nt, ok := types.Unalias(tp).(*types.Named)
+	if !ok {
+		return 0, false
+	}
+	ob := nt.Obj()
+	if ob.Pkg() == nil {
+		return 0, false
+	}
+	return vectorAlign(ob.Pkg().Path(), ob.Name())
+}
+
+// ReflectVectorAlign is the reflection version of [VectorAlign].
+func ReflectVectorAlign(tp reflect.Type) (int64, bool) {
+	return vectorAlign(tp.PkgPath(), tp.Name())
+}
+
+func vectorAlign(path, name string) (int64, bool) {
+	if path != SlTypePath && path != Math32Path {
+		return 0, false
+	}
+	al, ok := VectorTypes[name]
+	return al, ok
+}
+
+// VectorStride returns the array stride of a vector type of given size
+// and alignment under given layout: the size rounded up to the alignment,
+// or to 16 bytes for std140.
+func VectorStride(sz, align int64, layout Layouts) int64 {
+	if layout == Std140 {
+		align = 16
+	}
+	return ((sz + align - 1) / align) * align
+}
+
+// IsMatrixName returns true if the given name of one of
+// the [VectorTypes] is a matrix type (e.g., Float2x2).
+func IsMatrixName(tnm string) bool {
+	return strings.HasPrefix(tnm, "Float") && strings.Contains(tnm, "x")
+}
+
+// VectorErrors returns the errors for a vector or matrix type field of
+// given type name and alignment at given offset, under given layout,
+// each followed by a suggested fix.
+func VectorErrors(flName, tnm string, off, align int64, layout Layouts) []string {
+	var errs []string
+	if off%align != 0 {
+		kind := "vector"
+		if IsMatrixName(tnm) {
+			kind = "matrix"
+		}
+		errs = append(errs, fmt.Sprintf("    %s:  %s type: %s is not at mod-%d byte offset: %d", flName, kind, tnm, align, off), "        fix: "+PadFix(off, flName))
+	}
+	if layout == Std140 && tnm == "Float2x2" {
+		errs = append(errs, fmt.Sprintf("    %s:  matrix type: %s columns have a 16 byte stride in std140, not 8 as in Go", flName, tnm), "        fix: use two Float4 fields for the columns, or use a storage buffer")
+	}
+	return errs
+}
+
+// VectorStrideFix returns the suggested fix for an array of vectors
+// of given type name whose stride does not match the layout stride.
+func VectorStrideFix(tnm string) string {
+	return fmt.Sprintf("use an array of 4 component vectors (e.g., Float4) instead of %s", tnm)
+}
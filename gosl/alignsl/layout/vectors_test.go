@@ -0,0 +1,51 @@
+// Copyright (c) 2024, Cogent Core. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package layout
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomas-mraz/vgpu/gosl/sltype"
+)
+
+func TestVectorAlign(t *testing.T) {
+	type GoodVecs struct {
+		Pos   sltype.Float3
+		Mass  float32
+		Uv    sltype.Float2
+		Idx   sltype.Int2
+		Rot   sltype.Float3x3
+		Cells [2]sltype.Uint4
+	}
+	type BadVecs struct {
+		Mass float32
+		Uv   sltype.Float2
+		Pos  sltype.Float4
+		Rot  sltype.Float2x2
+		Pts  [2]sltype.Float3
+	}
+	assert.NoError(t, CheckType(reflect.TypeOf(GoodVecs{}), Std430))
+	assert.NoError(t, CheckType(reflect.TypeOf(GoodVecs{}), Std140))
+
+	err := CheckType(reflect.TypeOf(BadVecs{}), Std140)
+	assert.Equal(t, `alignsl: std140 layout errors in type BadVecs:
+BadVecs
+    Uv:  vector type: Vector2 is not at mod-8 byte offset: 4
+        fix: insert 3 padding float32 before field Uv
+    Pos:  vector type: Vector4 is not at mod-16 byte offset: 12
+        fix: insert 1 padding float32 before field Pos
+    Rot:  matrix type: Float2x2 is not at mod-8 byte offset: 28
+        fix: insert 1 padding float32 before field Rot
+    Rot:  matrix type: Float2x2 columns have a 16 byte stride in std140, not 8 as in Go
+        fix: use two Float4 fields for the columns, or use a storage buffer
+    Pts:  vector type: Vector3 is not at mod-16 byte offset: 44
+        fix: insert 1 padding float32 before field Pts
+    Pts:  array element stride: 12 != std140 stride: 16 -- element type: Vector3 size must be an even multiple of 16
+        fix: use an array of 4 component vectors (e.g., Float4) instead of Vector3
+    total size: 68 not even multiple of 16 -- needs 3 extra 32bit padding fields
+        fix: add 3 padding float32 after field Pts`, err.Error())
+}
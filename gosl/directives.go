@@ -0,0 +1,134 @@
+// Copyright (c) 2024, Cogent Core. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"github.com/tomas-mraz/vgpu/gosl/alignsl/layout"
+	"golang.org/x/tools/go/packages"
+)
+
+// DirectiveArg returns the argument of the given //gosl:<name> directive
+// in the given doc comment, and whether the directive was found.
+func DirectiveArg(doc *ast.CommentGroup, name string) (string, bool) {
+	if doc == nil {
+		return "", false
+	}
+	key := "//gosl:" + name
+	for _, c := range doc.List {
+		if !strings.HasPrefix(c.Text, key) {
+			continue
+		}
+		arg := c.Text[len(key):]
+		if arg != "" && arg[0] != ' ' && arg[0] != '\t' {
+			continue // different directive with same prefix
+		}
+		return strings.TrimSpace(arg), true
+	}
+	return "", false
+}
+
+// NameDirectives returns the HLSL names for all the objects in the
+// package that have a //gosl:name <hlslName> directive in the doc
+// comment of their declaration: functions, methods, and types, consts
+// and vars declared in a single (non-grouped) declaration.
+func NameDirectives(pkg *packages.Package) map[types.Object]string {
+	renames := map[types.Object]string{}
+	for _, sy := range pkg.Syntax {
+		for _, d := range sy.Decls {
+			nm, has := DirectiveArg(declDoc(d), "name")
+			if !has || nm == "" {
+				continue
+			}
+			if gd, ok := d.(*ast.GenDecl); ok && len(gd.Specs) != 1 {
+				continue
+			}
+			for _, ob := range declObjects(pkg, d) {
+				renames[ob] = nm
+				break
+			}
+		}
+	}
+	return renames
+}
+
+// KeepDirectives returns the methods in the package that have a
+// //gosl:keep directive in their doc comment, which are included
+// even if their name is excluded by the -exclude flag.
+func KeepDirectives(pkg *packages.Package) map[types.Object]bool {
+	keep := map[types.Object]bool{}
+	for _, sy := range pkg.Syntax {
+		for _, d := range sy.Decls {
+			fd, ok := d.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+			if _, has := DirectiveArg(fd.Doc, "keep"); has {
+				keep[pkg.TypesInfo.Defs[fd.Name]] = true
+			}
+		}
+	}
+	return keep
+}
+
+// LayoutDirectives returns the buffer layouts specified via a
+// //gosl:layout std140 (or std430) directive in the doc comment of
+// struct type declarations, keyed by type name.  These take
+// precedence over the layouts derived from buffer bindings.
+func LayoutDirectives(pkg *packages.Package) map[string]layout.Layouts {
+	layouts := map[string]layout.Layouts{}
+	for _, sy := range pkg.Syntax {
+		for _, d := range sy.Decls {
+			gd, ok := d.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+			for _, sp := range gd.Specs {
+				ts, ok := sp.(*ast.TypeSpec)
+				if !ok {
+					continue
+				}
+				doc := ts.Doc
+				if doc == nil && len(gd.Specs) == 1 {
+					doc = gd.Doc
+				}
+				arg, has := DirectiveArg(doc, "layout")
+				if !has {
+					continue
+				}
+				ly, err := layout.ParseLayout(arg)
+				if err != nil {
+					fmt.Printf("%s: %v\n", pkg.Fset.Position(ts.Pos()), err)
+					continue
+				}
+				layouts[ts.Name.Name] = ly
+			}
+		}
+	}
+	return layouts
+}
+
+// SkipDecls returns a copy of the given file without the declarations
+// that have a //gosl:skip directive in their doc comment.
+// Also returns the names of the skipped declarations.
+func SkipDecls(pkg *packages.Package, afile *ast.File) (*ast.File, []string) {
+	keep := make([]bool, len(afile.Decls))
+	hasSkip := false
+	for di, d := range afile.Decls {
+		_, skip := DirectiveArg(declDoc(d), "skip")
+		keep[di] = !skip
+		if skip {
+			hasSkip = true
+		}
+	}
+	if !hasSkip {
+		return afile, nil
+	}
+	return RemoveDecls(pkg, afile, keep)
+}
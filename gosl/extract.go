@@ -7,6 +7,8 @@ package main
 import (
 	"bytes"
 	"fmt"
+	"go/parser"
+	"go/token"
 	"io/ioutil"
 	"log"
 	"os"
@@ -28,14 +30,18 @@ func ReadFileLines(fn string) ([][]byte, error) {
 	return lines, nil
 }
 
-// Extracts comment-directive tagged regions from .go files
-func ExtractGoFiles(files []string) map[string][]byte {
+// Extracts comment-directive tagged regions from .go files.
+// Returns an error for a //gosl:cpu-only block that is not closed
+// by //gosl:end cpu-only.
+func ExtractGoFiles(files []string) (map[string][]byte, error) {
 	sls := map[string][][]byte{}
 	key := []byte("//gosl:")
 	start := []byte("start")
 	hlsl := []byte("hlsl")
 	nohlsl := []byte("nohlsl")
 	end := []byte("end")
+	cpuOnly := []byte("cpu-only")
+	endCPUOnly := []byte("end cpu-only")
 	nl := []byte("\n")
 	include := []byte("#include")
 
@@ -51,9 +57,17 @@ func ExtractGoFiles(files []string) map[string][]byte {
 		inReg := false
 		inHlsl := false
 		inNoHlsl := false
+		inCPU := false
 		var outLns [][]byte
 		slFn := ""
-		for _, ln := range lines {
+		// lineDir returns a //line directive for the source line
+		// following line index li, so that positions in the extracted
+		// Go file refer to the original source file.
+		absfn, _ := filepath.Abs(fn)
+		lineDir := func(li int) []byte {
+			return []byte(fmt.Sprintf("//line %s:%d", absfn, li+2))
+		}
+		for li, ln := range lines {
 			tln := bytes.TrimSpace(ln)
 			isKey := bytes.HasPrefix(tln, key)
 			var keyStr []byte
@@ -62,7 +76,17 @@ func ExtractGoFiles(files []string) map[string][]byte {
 				// fmt.Printf("key: %s\n", string(keyStr))
 			}
 			switch {
+			case inCPU && isKey && bytes.Equal(keyStr, endCPUOnly):
+				inCPU = false
+				outLns = append(outLns, lineDir(li))
+			case inCPU && !(isKey && bytes.HasPrefix(keyStr, end)):
+				// cpu-only code is not included
+			case inReg && isKey && bytes.Equal(keyStr, cpuOnly):
+				inCPU = true
 			case inReg && isKey && bytes.HasPrefix(keyStr, end):
+				if inCPU {
+					return nil, fmt.Errorf("%s:%d: //gosl:cpu-only block not closed by //gosl:end cpu-only before the end of the region", fn, li+1)
+				}
 				if inHlsl || inNoHlsl {
 					outLns = append(outLns, ln)
 				}
@@ -81,12 +105,14 @@ func ExtractGoFiles(files []string) map[string][]byte {
 				inReg = true
 				slFn = string(keyStr[len(start)+1:])
 				outLns = sls[slFn]
+				outLns = append(outLns, lineDir(li))
 			case isKey && bytes.HasPrefix(keyStr, nohlsl):
 				inReg = true
 				inNoHlsl = true
 				slFn = string(keyStr[len(nohlsl)+1:])
 				outLns = sls[slFn]
 				outLns = append(outLns, ln) // key to include self here
+				outLns = append(outLns, lineDir(li))
 			case isKey && bytes.HasPrefix(keyStr, hlsl):
 				inReg = true
 				inHlsl = true
@@ -95,6 +121,9 @@ func ExtractGoFiles(files []string) map[string][]byte {
 				outLns = append(outLns, ln)
 			}
 		}
+		if inCPU {
+			return nil, fmt.Errorf("%s: //gosl:cpu-only block not closed by //gosl:end cpu-only", fn)
+		}
 	}
 
 	rsls := make(map[string][]byte)
@@ -104,6 +133,7 @@ func ExtractGoFiles(files []string) map[string][]byte {
 		olns = append(olns, []byte("package main"))
 		olns = append(olns, []byte(`import (
 	"math"
+	"cogentcore.org/core/math32"
 	"github.com/tomas-mraz/vgpu/gosl/slbool"
 	"github.com/tomas-mraz/vgpu/gosl/slrand"
 	"github.com/tomas-mraz/vgpu/gosl/sltype"
@@ -119,11 +149,49 @@ func ExtractGoFiles(files []string) map[string][]byte {
 		// fmt.Printf("\n################\ngoimports output for: %s\n%s\n", outfn, out)
 		if err != nil {
 			log.Println(err)
+		} else {
+			RestoreLines(outfn, bytes.Join(lns, nl))
 		}
 		rsls[fn] = bytes.Join(lns, nl)
 	}
 
-	return rsls
+	return rsls, nil
+}
+
+// RestoreLines replaces everything after the imports in the given
+// Go file that has been processed by goimports with the given extracted
+// lines, as goimports moves the //line directives in them into the
+// doc comments that follow, which breaks their positions.
+func RestoreLines(fn string, lines []byte) {
+	src, err := os.ReadFile(fn)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, fn, src, parser.ImportsOnly)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	end := f.Name.End()
+	for _, d := range f.Decls {
+		end = d.End()
+	}
+	hdr := src[:fset.Position(end).Offset]
+	ioutil.WriteFile(fn, slices.Concat(hdr, []byte("\n"), lines), 0644)
+}
+
+// DeclDirectives are the //gosl: directives used in the doc comments
+// of declarations, which are removed from the HLSL output.
+var DeclDirectives = []string{"keep", "kernel", "layout", "name", "skip"}
+
+// IsDeclDirective returns true if the given directive key string
+// (after //gosl:) is one of the [DeclDirectives], comparing the
+// whole directive word before any arguments.
+func IsDeclDirective(keyStr []byte) bool {
+	word, _, _ := bytes.Cut(keyStr, []byte(" "))
+	return slices.Contains(DeclDirectives, string(word))
 }
 
 // ExtractHLSL extracts the HLSL code embedded within .Go files.
@@ -140,6 +208,7 @@ func ExtractHLSL(buf []byte) ([]byte, bool) {
 	pack := []byte("package")
 	imp := []byte("import")
 	main := []byte("void main(")
+	lineDir := []byte("//line ")
 	lparen := []byte("(")
 	rparen := []byte(")")
 
@@ -166,6 +235,30 @@ func ExtractHLSL(buf []byte) ([]byte, bool) {
 
 	lines = lines[stln:] // get rid of package, import
 
+	// remove //line directives, and directives on declarations
+	// along with an empty comment line before
+	for li := 0; li < len(lines); li++ {
+		tln := bytes.TrimSpace(lines[li])
+		if bytes.HasPrefix(tln, lineDir) {
+			st := li
+			if li > 0 && li+1 < len(lines) && len(bytes.TrimSpace(lines[li-1])) == 0 && len(bytes.TrimSpace(lines[li+1])) == 0 {
+				st-- // don't leave two blank lines
+			}
+			lines = slices.Delete(lines, st, li+1)
+			li = st - 1
+			continue
+		}
+		if !bytes.HasPrefix(tln, key) || !IsDeclDirective(tln[len(key):]) {
+			continue
+		}
+		st := li
+		if li > 0 && bytes.Equal(bytes.TrimSpace(lines[li-1]), []byte("//")) {
+			st--
+		}
+		lines = slices.Delete(lines, st, li+1)
+		li = st - 1
+	}
+
 	hasMain := false
 	inHlsl := false
 	inNoHlsl := false
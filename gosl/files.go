@@ -30,6 +30,11 @@ func IsHLSLFile(f fs.DirEntry) bool {
 	return !strings.HasPrefix(name, ".") && strings.HasSuffix(name, ".hlsl") && !f.IsDir()
 }
 
+func IsLayoutFile(f fs.DirEntry) bool {
+	name := f.Name()
+	return !strings.HasPrefix(name, ".") && strings.HasSuffix(name, ".layout.json") && !f.IsDir()
+}
+
 func IsSPVFile(f fs.DirEntry) bool {
 	name := f.Name()
 	return !strings.HasPrefix(name, ".") && strings.HasSuffix(name, ".spv") && !f.IsDir()
@@ -170,13 +175,13 @@ func CopySlrand() error {
 	return nil
 }
 
-// RemoveGenFiles removes .go, .hlsl, .spv files in shader generated dir
+// RemoveGenFiles removes .go, .hlsl, .spv, .layout.json files in shader generated dir
 func RemoveGenFiles(dir string) {
 	err := filepath.WalkDir(dir, func(path string, f fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
-		if IsGoFile(f) || IsHLSLFile(f) || IsSPVFile(f) {
+		if IsGoFile(f) || IsHLSLFile(f) || IsSPVFile(f) || IsLayoutFile(f) {
 			os.Remove(path)
 		}
 		return nil
@@ -25,6 +25,9 @@ var (
 	excludeFunctions   = flag.String("exclude", "Update,Defaults", "comma-separated list of names of functions to exclude from exporting to HLSL")
 	keepTmp            = flag.Bool("keep", false, "keep temporary converted versions of the source files, for debugging")
 	debug              = flag.Bool("debug", false, "enable debugging messages while running")
+	f32                = flag.Bool("f32", false, "narrow float64 local variables, constants and math calls to float32 in the generated HLSL, and print a report of every narrowing site")
+	prune              = flag.Bool("prune", false, "only include the types, constants and functions reachable from the main function or //gosl:kernel functions in each kernel file; off by default, so that the output of existing code does not change")
+	allow              = flag.String("allow", "", "comma-separated list of field types allowed in structs by the alignment checking, in addition to int32, uint32 and float32 and the default uint64 and slbool: uint64, float64, slbool, with a - prefix to not allow a default type (e.g., -slbool)")
 	excludeFunctionMap = map[string]bool{}
 )
 
@@ -5,7 +5,9 @@
 package main
 
 import (
+	"bufio"
 	"bytes"
+	"encoding/json"
 	"flag"
 	"os"
 	"path/filepath"
@@ -17,8 +19,60 @@ import (
 
 var update = flag.Bool("update", false, "update .golden files")
 
+// goslFlags looks for a comment of the form
+//
+//	//gosl flags
+//
+// within the first maxLines lines of the given file,
+// and returns the (possibly empty) string of flags.
+func goslFlags(filename string, maxLines int) string {
+	f, err := os.Open(filename)
+	if err != nil {
+		return "" // ignore errors - they will be found later
+	}
+	defer f.Close()
+
+	// read the first maxLines lines
+	const prefix = "//gosl flags"
+	s := bufio.NewScanner(f)
+	for i := 0; i < maxLines && s.Scan(); i++ {
+		line := s.Text()
+		if strings.HasPrefix(line, prefix) {
+			return strings.TrimSpace(line[len(prefix):])
+		}
+	}
+	return ""
+}
+
 func runTest(t *testing.T, in, out string) {
 	// process flags
+	*prune = false
+	*f32 = false
+	*excludeFunctions = ""
+	defer func() { excludeFunctionMap = map[string]bool{} }()
+	for _, flag := range strings.Split(goslFlags(in, 20), " ") {
+		elts := strings.SplitN(flag, "=", 2)
+		name := elts[0]
+		value := ""
+		if len(elts) == 2 {
+			value = elts[1]
+		}
+		switch name {
+		case "":
+			// no flags
+		case "-prune":
+			*prune = value != "false"
+		case "-f32":
+			*f32 = value != "false"
+		case "-exclude":
+			*excludeFunctions = value
+		default:
+			t.Errorf("unrecognized flag name: %s", name)
+		}
+	}
+
+	GoslArgs()
+
 	_, err := os.Lstat(in)
 	if err != nil {
 		t.Error(err)
@@ -96,3 +150,95 @@ func TestRewrite(t *testing.T) {
 		})
 	}
 }
+
+func TestNarrowingReport(t *testing.T) {
+	defer func() { *f32 = false }()
+	*f32 = true
+	_, err := ProcessFiles([]string{"testdata/f32.go"})
+	assert.NoError(t, err)
+	var b bytes.Buffer
+	NarrowingReport(&b, Narrowings)
+	expected := `
+-f32 float64 to float32 narrowing report: 10 sites
+    testdata/f32.go:10: float64 constant: Decay
+    testdata/f32.go:13: float64 type
+    testdata/f32.go:16: float64 type
+    testdata/f32.go:16: float64 type
+    testdata/f32.go:17: float64 local: nv
+    testdata/f32.go:18: float64 local: ex
+    testdata/f32.go:18: math call: math.Exp
+    testdata/f32.go:19: float64 local: sum
+    testdata/f32.go:19: float64 type
+    testdata/f32.go:23: math call: math.Sqrt
+`
+	assert.Equal(t, expected, b.String())
+}
+
+func TestLayout(t *testing.T) {
+	_, err := ProcessFiles([]string{"testdata/arrays.go"})
+	assert.NoError(t, err)
+	b, err := os.ReadFile(filepath.Join(*outDir, "arrays.layout.json"))
+	assert.NoError(t, err)
+	var kl KernelLayout
+	assert.NoError(t, json.Unmarshal(b, &kl))
+	assert.Equal(t, "arrays", kl.Kernel)
+	assert.Len(t, kl.Structs, 2)
+	assert.Equal(t, "Chan", kl.Structs[0].Name) // dependency first
+	ap := kl.Structs[1]
+	assert.Equal(t, "ArrayParams", ap.Name)
+	assert.Equal(t, int64(80), ap.Size)
+	chans := ap.Fields[1]
+	assert.Equal(t, "Chans", chans.Name)
+	assert.Equal(t, "Chan", chans.Type)
+	assert.Equal(t, int64(16), chans.Offset)
+	assert.Equal(t, int64(16), chans.ArrayStride)
+	idxs := ap.Fields[2]
+	assert.Equal(t, []int64{2, 4}, idxs.ArrayDims)
+	assert.Equal(t, int64(48), idxs.Offset)
+	assert.Equal(t, int64(16), idxs.ArrayStride)
+}
+
+func TestBindings(t *testing.T) {
+	hlsl := `[[vk::binding(0, 0)]] StructuredBuffer<ParamStruct> Params;
+[[vk::binding(2)]] RWStructuredBuffer<float> Data;`
+	bbs := Bindings([]byte(hlsl))
+	assert.Equal(t, []BufferBinding{
+		{Name: "Params", Set: 0, Binding: 0, Buffer: "StructuredBuffer", Type: "ParamStruct", Role: "Storage"},
+		{Name: "Data", Set: 0, Binding: 2, Buffer: "RWStructuredBuffer", Type: "float", Role: "Storage"},
+	}, bbs)
+	assert.Equal(t, "Uniform", BufferRole("ConstantBuffer"))
+}
+
+func TestDirectives(t *testing.T) {
+	assert.True(t, IsDeclDirective([]byte("skip")))
+	assert.True(t, IsDeclDirective([]byte("name KernelParams")))
+	assert.False(t, IsDeclDirective([]byte("namespace")))
+	assert.False(t, IsDeclDirective([]byte("kernels")))
+
+	od := *outDir
+	defer func() { *outDir = od }()
+	*outDir = t.TempDir()
+	fn := filepath.Join(t.TempDir(), "cpu.go")
+	src := `package test
+
+//gosl:start cpu
+func Compute(x float32) float32 {
+	//gosl:cpu-only
+	x = 0
+	//gosl:end
+	return x
+}
+//gosl:end cpu
+`
+	assert.NoError(t, os.WriteFile(fn, []byte(src), 0666))
+	// an unqualified end does not close the cpu-only block
+	_, err := ExtractGoFiles([]string{fn})
+	assert.ErrorContains(t, err, "cpu.go:7: //gosl:cpu-only block not closed by //gosl:end cpu-only")
+
+	src = strings.ReplaceAll(src, "//gosl:end\n", "//gosl:end cpu-only\n")
+	assert.NoError(t, os.WriteFile(fn, []byte(src), 0666))
+	sls, err := ExtractGoFiles([]string{fn})
+	assert.NoError(t, err)
+	assert.NotContains(t, string(sls["cpu"]), "x = 0")
+	assert.Contains(t, string(sls["cpu"]), "return x")
+}
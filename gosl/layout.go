@@ -0,0 +1,185 @@
+// Copyright (c) 2024, Cogent Core. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/types"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/tomas-mraz/vgpu/gosl/alignsl/layout"
+	"golang.org/x/tools/go/packages"
+)
+
+// KernelLayout is the memory layout metadata for the structs and
+// buffers used in a generated HLSL file, which is written to
+// a <name>.layout.json file alongside the <name>.hlsl file,
+// for use by external tools.
+type KernelLayout struct {
+
+	// name of the HLSL file, without extension
+	Kernel string `json:"kernel"`
+
+	// layouts of the struct types defined or used in the file,
+	// with dependencies before the structs that use them.
+	// Struct names are the HLSL names.
+	Structs []*layout.StructLayout `json:"structs"`
+
+	// buffers bound in the HLSL code
+	Buffers []BufferBinding `json:"buffers"`
+}
+
+// BufferBinding is a buffer bound via a [[vk::binding]] attribute.
+type BufferBinding struct {
+
+	// name of the buffer variable
+	Name string `json:"name"`
+
+	// descriptor set
+	Set int `json:"set"`
+
+	// binding within the set
+	Binding int `json:"binding"`
+
+	// HLSL resource type, e.g., StructuredBuffer or RWStructuredBuffer
+	Buffer string `json:"buffer"`
+
+	// element type of the buffer
+	Type string `json:"type"`
+
+	// vgpu.VarRoles role of the buffer: Uniform or Storage,
+	// determined by the Buffer type (see [BufferRole])
+	Role string `json:"role"`
+}
+
+// BufferRole returns the vgpu.VarRoles name for the given HLSL
+// resource type: Uniform for ConstantBuffer and cbuffer, Storage
+// for structured and byte address buffers, and empty otherwise.
+func BufferRole(buffer string) string {
+	switch {
+	case buffer == "ConstantBuffer" || buffer == "cbuffer":
+		return "Uniform"
+	case strings.HasSuffix(buffer, "StructuredBuffer") || strings.HasSuffix(buffer, "ByteAddressBuffer"):
+		return "Storage"
+	}
+	return ""
+}
+
+// bindingRe matches HLSL buffer bindings, e.g.:
+// [[vk::binding(0, 1)]] RWStructuredBuffer<DataStruct> Data;
+var bindingRe = regexp.MustCompile(`\[\[vk::binding\(\s*(\d+)\s*(?:,\s*(\d+)\s*)?\)\]\]\s*(\w+)\s*(?:<\s*(\w+)\s*>)?\s+(\w+)`)
+
+// Bindings returns the buffer bindings in the given HLSL code.
+func Bindings(hlsl []byte) []BufferBinding {
+	var bbs []BufferBinding
+	for _, m := range bindingRe.FindAllSubmatch(hlsl, -1) {
+		bb := BufferBinding{Buffer: string(m[3]), Type: string(m[4]), Name: string(m[5])}
+		bb.Role = BufferRole(bb.Buffer)
+		bb.Binding, _ = strconv.Atoi(string(m[1]))
+		if len(m[2]) > 0 {
+			bb.Set, _ = strconv.Atoi(string(m[2]))
+		}
+		bbs = append(bbs, bb)
+	}
+	return bbs
+}
+
+// NewKernelLayout returns the [KernelLayout] for the given file and
+// its final HLSL code, using the package types.Sizes, as used for
+// [alignsl.CheckPackage]. Structs include those defined in the file
+// and those used as buffer element types, with their dependencies.
+func NewKernelLayout(pkg *packages.Package, afile *ast.File, fn string, hlsl []byte, renames map[types.Object]string) *KernelLayout {
+	kl := &KernelLayout{Kernel: fn, Structs: []*layout.StructLayout{}, Buffers: Bindings(hlsl)}
+	if kl.Buffers == nil {
+		kl.Buffers = []BufferBinding{}
+	}
+	hlslNames := map[string]types.Object{}
+	for ob, nm := range renames {
+		hlslNames[nm] = ob
+	}
+	has := map[*types.Named]bool{}
+	add := func(nt *types.Named) {
+		st, ok := nt.Underlying().(*types.Struct)
+		if !ok || has[nt] {
+			return
+		}
+		for _, dep := range append(layout.StructDeps(st), nt) {
+			if has[dep] {
+				continue
+			}
+			has[dep] = true
+			nm := dep.Obj().Name()
+			if rn, ok := renames[dep.Obj()]; ok {
+				nm = rn
+			}
+			kl.Structs = append(kl.Structs, layout.Layout(pkg.TypesSizes, nm, dep.Underlying().(*types.Struct)))
+		}
+	}
+	for _, d := range afile.Decls {
+		gd, ok := d.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, sp := range gd.Specs {
+			if ts, ok := sp.(*ast.TypeSpec); ok {
+				if nt, ok := pkg.TypesInfo.Defs[ts.Name].Type().(*types.Named); ok {
+					add(nt)
+				}
+			}
+		}
+	}
+	for _, bb := range kl.Buffers {
+		ob := hlslNames[bb.Type]
+		if ob == nil {
+			ob = pkg.Types.Scope().Lookup(bb.Type)
+		}
+		if ob == nil {
+			continue
+		}
+		if nt, ok := ob.Type().(*types.Named); ok {
+			add(nt)
+		}
+	}
+	return kl
+}
+
+// BufferLayouts adds to the given map the target [layout.Layouts]
+// for the struct types used as buffer element types in the given HLSL
+// code, keyed by Go type name, derived from the role of the buffer (see [BufferRole]).
+// A struct bound as both a Uniform and a Storage buffer gets the more
+// restrictive Std140 layout.
+func BufferLayouts(pkg *packages.Package, hlsl []byte, renames map[types.Object]string, layouts map[string]layout.Layouts) {
+	hlslNames := map[string]types.Object{}
+	for ob, nm := range renames {
+		hlslNames[nm] = ob
+	}
+	for _, bb := range Bindings(hlsl) {
+		nm := bb.Type
+		if ob := hlslNames[nm]; ob != nil {
+			nm = ob.Name()
+		}
+		if pkg.Types.Scope().Lookup(nm) == nil {
+			continue
+		}
+		if ly := layout.RoleLayout(bb.Role); ly > layouts[nm] {
+			layouts[nm] = ly
+		}
+	}
+}
+
+// WriteLayout writes the [KernelLayout] for given file to
+// <fn>.layout.json in the output directory.
+func WriteLayout(kl *KernelLayout) error {
+	b, err := json.MarshalIndent(kl, "", "\t")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(*outDir, kl.Kernel+".layout.json"), append(b, '\n'), 0644)
+}
@@ -12,17 +12,22 @@ package main
 
 import (
 	"bytes"
+	"cmp"
 	"fmt"
 	"go/ast"
 	"go/token"
+	"io"
 	"io/ioutil"
 	"log"
+	"maps"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/tomas-mraz/vgpu/gosl/alignsl"
+	"github.com/tomas-mraz/vgpu/gosl/alignsl/layout"
 	"github.com/tomas-mraz/vgpu/gosl/slprint"
 	"golang.org/x/tools/go/packages"
 )
@@ -30,7 +35,11 @@ import (
 // does all the file processing
 func ProcessFiles(paths []string) (map[string][]byte, error) {
 	fls := FilesFromPaths(paths)
-	gosls := ExtractGoFiles(fls) // extract Go files to shader/*.go
+	gosls, err := ExtractGoFiles(fls) // extract Go files to shader/*.go
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
 
 	hlslFiles := []string{}
 	for _, fn := range fls {
@@ -63,10 +72,31 @@ func ProcessFiles(paths []string) (map[string][]byte, error) {
 	// map of files with a main function that needs to be compiled
 	needsCompile := map[string]bool{}
 
-	serr := alignsl.CheckPackage(pkg)
+	renames := NameDirectives(pkg)
+	keep := KeepDirectives(pkg)
+
+	// struct layouts from buffer bindings, overridden by directives
+	layouts := map[string]layout.Layouts{}
+	for fn := range gosls {
+		for _, sy := range pkg.Syntax {
+			pos := pkg.Fset.Position(sy.Package)
+			if filepath.Base(pos.Filename) == fn+".go" {
+				BufferLayouts(pkg, KernelRootText(pos.Filename, fn, hlslFiles), renames, layouts)
+			}
+		}
+	}
+	maps.Copy(layouts, LayoutDirectives(pkg))
+
+	pol, err := layout.ParsePolicy(*allow)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	serr := alignsl.CheckPackageOptions(pkg, alignsl.Options{Policy: pol, Layouts: layouts})
 	if serr != nil {
 		fmt.Println(serr)
 	}
+	Narrowings = nil
 
 	slrandCopied := false
 	for fn := range gosls {
@@ -91,8 +121,20 @@ func ProcessFiles(paths []string) (map[string][]byte, error) {
 			continue
 		}
 
+		afile, skipped := SkipDecls(pkg, afile)
+		if *debug && len(skipped) > 0 {
+			fmt.Printf("\tskipped: %s\n", strings.Join(skipped, ", "))
+		}
+		if *prune {
+			var pruned []string
+			afile, pruned = PruneFile(pkg, afile, KernelRootText(fpos.Filename, fn, hlslFiles), renames)
+			if *debug && len(pruned) > 0 {
+				fmt.Printf("\tpruned unreachable: %s\n", strings.Join(pruned, ", "))
+			}
+		}
+
 		var buf bytes.Buffer
-		cfg := slprint.Config{Mode: printerMode, Tabwidth: tabWidth, ExcludeFunctions: excludeFunctionMap}
+		cfg := slprint.Config{Mode: printerMode, Tabwidth: tabWidth, ExcludeFunctions: excludeFunctionMap, Keep: keep, Renames: renames, F32: *f32, Narrowings: &Narrowings}
 		cfg.Fprint(&buf, pkg, fpos, afile)
 		// ioutil.WriteFile(filepath.Join(*outDir, fn+".tmp"), buf.Bytes(), 0644)
 		slfix, hasSlrand := SlEdits(buf.Bytes())
@@ -138,6 +180,10 @@ func ProcessFiles(paths []string) (map[string][]byte, error) {
 
 		slfn := filepath.Join(*outDir, fn+".hlsl")
 		ioutil.WriteFile(slfn, exsl, 0644)
+
+		if err := WriteLayout(NewKernelLayout(pkg, afile, fn, exsl, renames)); err != nil {
+			fmt.Println(err)
+		}
 	}
 
 	// check for hlsl files that had no go equivalent
@@ -159,12 +205,36 @@ func ProcessFiles(paths []string) (map[string][]byte, error) {
 		needsCompile[fn] = true // assume any standalone hlsl is a main
 	}
 
+	if *f32 {
+		NarrowingReport(os.Stdout, Narrowings)
+	}
+
 	for fn := range needsCompile {
 		CompileFile(fn + ".hlsl")
 	}
 	return gosls, nil
 }
 
+// Narrowings are the float64 to float32 narrowing sites found
+// in the -f32 mode by the last call to [ProcessFiles].
+var Narrowings []slprint.Narrowing
+
+// NarrowingReport writes the list of float64 to float32 narrowing
+// sites from the -f32 mode to the given writer, sorted by Go source position.
+func NarrowingReport(w io.Writer, nrs []slprint.Narrowing) {
+	slices.SortStableFunc(nrs, func(a, b slprint.Narrowing) int {
+		return cmp.Or(cmp.Compare(a.Pos.Filename, b.Pos.Filename), cmp.Compare(a.Pos.Line, b.Pos.Line), cmp.Compare(a.Pos.Column, b.Pos.Column))
+	})
+	fmt.Fprintf(w, "\n-f32 float64 to float32 narrowing report: %d sites\n", len(nrs))
+	wd, _ := os.Getwd()
+	for _, nr := range nrs {
+		if rfn, err := filepath.Rel(wd, nr.Pos.Filename); err == nil {
+			nr.Pos.Filename = rfn
+		}
+		fmt.Fprintf(w, "    %s\n", nr.String())
+	}
+}
+
 func CompileFile(fn string) error {
 	ext := filepath.Ext(fn)
 	ofn := fn[:len(fn)-len(ext)] + ".spv"
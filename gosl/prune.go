@@ -0,0 +1,220 @@
+// Copyright (c) 2024, Cogent Core. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/types"
+	"os"
+	"regexp"
+	"slices"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// hlslIdent matches identifiers in HLSL code
+var hlslIdent = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*`)
+
+// KernelRootText returns the HLSL code for given kernel file name fn,
+// from the //gosl:hlsl regions in the extracted Go source file
+// and the corresponding .hlsl file if any, which is where the main
+// function and its references to Go-defined code are found.
+func KernelRootText(srcfn, fn string, hlslFiles []string) []byte {
+	key := []byte("//gosl:")
+	hlsl := []byte("hlsl")
+	end := []byte("end")
+
+	var code [][]byte
+	lines, err := ReadFileLines(srcfn)
+	if err == nil {
+		inHlsl := false
+		for _, ln := range lines {
+			tln := bytes.TrimSpace(ln)
+			isKey := bytes.HasPrefix(tln, key)
+			var keyStr []byte
+			if isKey {
+				keyStr = tln[len(key):]
+			}
+			switch {
+			case inHlsl && isKey && bytes.HasPrefix(keyStr, end):
+				inHlsl = false
+			case inHlsl:
+				code = append(code, ln)
+			case isKey && bytes.HasPrefix(keyStr, hlsl):
+				inHlsl = true
+			}
+		}
+	}
+	for _, hlfn := range hlslFiles {
+		if fn+".hlsl" != hlfn {
+			continue
+		}
+		buf, err := os.ReadFile(hlfn)
+		if err == nil {
+			code = append(code, buf)
+		}
+	}
+	return bytes.Join(code, []byte("\n"))
+}
+
+// IsKernelFunc returns true if the given function has a
+// //gosl:kernel directive in its doc comment, marking it as a
+// kernel entry point for reachability analysis.
+func IsKernelFunc(fd *ast.FuncDecl) bool {
+	_, has := DirectiveArg(fd.Doc, "kernel")
+	return has
+}
+
+// declObjects returns the package-level objects defined by given decl
+func declObjects(pkg *packages.Package, decl ast.Decl) []types.Object {
+	var objs []types.Object
+	add := func(id *ast.Ident) {
+		if ob := pkg.TypesInfo.Defs[id]; ob != nil {
+			objs = append(objs, ob)
+		}
+	}
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		add(d.Name)
+	case *ast.GenDecl:
+		for _, sp := range d.Specs {
+			switch s := sp.(type) {
+			case *ast.TypeSpec:
+				add(s.Name)
+			case *ast.ValueSpec:
+				for _, nm := range s.Names {
+					add(nm)
+				}
+			}
+		}
+	}
+	return objs
+}
+
+// PruneFile returns a copy of the given file with only the declarations
+// that are reachable from the kernel entry points: identifiers used in
+// the HLSL main code (see [KernelRootText]) and functions marked with
+// //gosl:kernel. Reachability follows the type-checked uses of objects
+// within each declaration, so called functions and methods, and referenced
+// types and constants are retained. A const or var group is retained
+// as a whole, to preserve iota values. If the file has no kernel entry
+// points, it is returned as-is, as it is an include file.
+// Also returns the names of the pruned declarations.
+// Objects renamed via //gosl:name directives are also matched by their
+// HLSL name.
+func PruneFile(pkg *packages.Package, afile *ast.File, rootText []byte, renames map[types.Object]string) (*ast.File, []string) {
+	hasMain := bytes.Contains(rootText, []byte("void main("))
+
+	objDecl := map[types.Object]int{} // object to decl index
+	nameDecls := map[string][]int{}   // name to decl indexes
+	reach := make([]bool, len(afile.Decls))
+	var todo []int
+	mark := func(di int) {
+		if !reach[di] {
+			reach[di] = true
+			todo = append(todo, di)
+		}
+	}
+	for di, d := range afile.Decls {
+		for _, ob := range declObjects(pkg, d) {
+			objDecl[ob] = di
+			nameDecls[ob.Name()] = append(nameDecls[ob.Name()], di)
+			if nm, has := renames[ob]; has {
+				nameDecls[nm] = append(nameDecls[nm], di)
+			}
+		}
+		if fd, ok := d.(*ast.FuncDecl); ok && IsKernelFunc(fd) {
+			hasMain = true
+			mark(di)
+		}
+	}
+	if !hasMain {
+		return afile, nil
+	}
+	if len(rootText) > 0 {
+		for _, id := range hlslIdent.FindAll(rootText, -1) {
+			for _, di := range nameDecls[string(id)] {
+				mark(di)
+			}
+		}
+	}
+	for len(todo) > 0 {
+		di := todo[len(todo)-1]
+		todo = todo[:len(todo)-1]
+		ast.Inspect(afile.Decls[di], func(n ast.Node) bool {
+			if id, ok := n.(*ast.Ident); ok {
+				if ob := pkg.TypesInfo.Uses[id]; ob != nil {
+					if udi, has := objDecl[ob]; has {
+						mark(udi)
+					}
+				}
+			}
+			return true
+		})
+	}
+
+	return RemoveDecls(pkg, afile, reach)
+}
+
+// RemoveDecls returns a copy of the given file with only the
+// declarations that have a true value in the keep list, also removing
+// the comments of the removed declarations so they are not printed.
+// Also returns the names of the removed declarations, sorted.
+func RemoveDecls(pkg *packages.Package, afile *ast.File, keep []bool) (*ast.File, []string) {
+	var removed []string
+	nf := *afile
+	nf.Decls = nil
+	nf.Comments = slices.Clone(afile.Comments)
+	for di, d := range afile.Decls {
+		if keep[di] {
+			nf.Decls = append(nf.Decls, d)
+			continue
+		}
+		for _, ob := range declObjects(pkg, d) {
+			removed = append(removed, objName(ob))
+		}
+		st := d.Pos()
+		if doc := declDoc(d); doc != nil {
+			st = doc.Pos()
+		}
+		ed := d.End()
+		edLine := pkg.Fset.PositionFor(ed, false).Line
+		nf.Comments = slices.DeleteFunc(nf.Comments, func(cg *ast.CommentGroup) bool {
+			return cg.Pos() >= st && (cg.End() <= ed || pkg.Fset.PositionFor(cg.Pos(), false).Line == edLine)
+		})
+	}
+	slices.Sort(removed)
+	return &nf, removed
+}
+
+// declDoc returns the doc comment for given decl, if any
+func declDoc(decl ast.Decl) *ast.CommentGroup {
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		return d.Doc
+	case *ast.GenDecl:
+		return d.Doc
+	}
+	return nil
+}
+
+// objName returns the name of given object, including the receiver
+// type name for methods.
+func objName(ob types.Object) string {
+	if fn, ok := ob.(*types.Func); ok {
+		if recv := fn.Type().(*types.Signature).Recv(); recv != nil {
+			rt := recv.Type()
+			if pt, ok := rt.(*types.Pointer); ok {
+				rt = pt.Elem()
+			}
+			if nt, ok := rt.(*types.Named); ok {
+				return fmt.Sprintf("%s.%s", nt.Obj().Name(), ob.Name())
+			}
+		}
+	}
+	return ob.Name()
+}
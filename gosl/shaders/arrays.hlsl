@@ -0,0 +1,50 @@
+#ifndef __ARRAYS_HLSL__
+#define __ARRAYS_HLSL__
+
+
+// Chan has the parameters for one channel
+struct Chan {
+
+	// channel gain
+	float Gbar;
+
+	// reversal potential
+	float Erev;
+
+	float pad, pad1;
+};
+
+// ArrayParams has fixed-size array fields
+struct ArrayParams {
+
+	// gains for each pathway
+	float Gains[4];
+
+	// channels
+	Chan Chans[2];
+
+	// index table
+	int Idxs[2][4];
+	float SumGains(inout float facs[4]) {
+		float sum = float(0);
+		for (int i = 0; i < 4; i++) {
+			sum += this.Gains[i] * facs[i];
+		}
+		return sum;
+	}
+
+	void SetGains(float g) {
+		float loc[4];
+		float ones[4] = {1, 1, 1, 1};
+		int zeros[2][2] = {{0, 0}, {0, 0}};
+		for (int i = 0; i < 4; i++) {
+			loc[i] = g * ones[i];
+			this.Gains[i] = loc[i];
+		}
+		this.Chans[0].Gbar = g;
+		this.Idxs[1][2] = zeros[0][1];
+	}
+
+};
+
+#endif // __ARRAYS_HLSL__
@@ -0,0 +1,35 @@
+#ifndef __BITS_HLSL__
+#define __BITS_HLSL__
+
+
+// Spiked is the flag for a neuron that spiked
+static const uint Spiked = 0;
+
+// Bursting is the flag for a bursting neuron
+static const uint Bursting = 1;
+
+// Neuron has packed boolean flags
+struct Neuron {
+
+	// boolean flags
+	uint Flags;
+
+	// number of flags set
+	uint NFlags;
+
+	float pad, pad1;
+	void Update(bool spike) {
+		if (spike) {
+			this.Flags |= (1u << Spiked);
+		} else {
+			this.Flags &= ~(1u << Spiked);
+		}
+		if (((this.Flags & (1u << Spiked)) != 0) && !((this.Flags & (1u << (Bursting+1))) != 0)) {
+			this.Flags ^= (1u << Bursting);
+		}
+		this.NFlags = countbits(this.Flags);
+	}
+
+};
+
+#endif // __BITS_HLSL__
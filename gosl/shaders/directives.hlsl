@@ -0,0 +1,31 @@
+#ifndef __DIRECTIVES_HLSL__
+#define __DIRECTIVES_HLSL__
+
+
+// DirParams are the kernel params
+struct KernelParams {
+	float Gain;
+	float Off;
+
+	float pad, pad1;
+	void Defaults() {
+		this.Gain = 1;
+		this.Off = 0;
+	}
+
+	float ComputeOut(float x) {
+		float y = this.Gain*x + this.Off;
+		return y;
+	}
+
+};
+
+[[vk::binding(0, 0)]] StructuredBuffer<KernelParams> Params;
+[[vk::binding(0, 1)]] RWStructuredBuffer<float> Data;
+[numthreads(64, 1, 1)]
+void main(uint3 idx : SV_DispatchThreadID) {
+    KernelParams pars = Params[0];
+    pars.Defaults();
+    Data[idx.x] = pars.ComputeOut(Data[idx.x]);
+}
+#endif // __DIRECTIVES_HLSL__
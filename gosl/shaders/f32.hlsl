@@ -0,0 +1,21 @@
+#ifndef __F32_HLSL__
+#define __F32_HLSL__
+
+
+// Decay is the decay constant
+const float Decay = 0.95;
+
+// Rate is a typed double constant
+const float Rate = 0.1;
+
+// Integrate integrates the value using double reference code
+float Integrate(float v, float dt) {
+	float nv = v * Decay;
+	float ex = exp(-dt * Rate);
+	float sum = float(0);
+	for (int i = 0; i < 4; i++) {
+		sum += nv * ex;
+	}
+	return sqrt(sum);
+}
+#endif // __F32_HLSL__
@@ -0,0 +1,35 @@
+#ifndef __PRUNE_HLSL__
+#define __PRUNE_HLSL__
+
+
+// PruneModes are the modes, only some of which are used
+typedef int PruneModes;
+
+
+static const PruneModes PruneOff = 0;
+static const PruneModes PruneOn  = 1;
+
+// Scale is used by the kernel via Compute
+float Scale(float x) { return 2 * x; }
+
+// PruneParams are the kernel params
+struct PruneParams {
+	PruneModes Mode;
+	float    Gain;
+
+	float pad, pad1;
+	void Compute(inout float x) {
+		if (this.Mode == PruneOn) {
+			x = Scale(x) * this.Gain;
+		}
+	}
+
+};
+
+[[vk::binding(0, 0)]] StructuredBuffer<PruneParams> Params;
+[[vk::binding(0, 1)]] RWStructuredBuffer<float> Data;
+[numthreads(64, 1, 1)]
+void main(uint3 idx : SV_DispatchThreadID) {
+    Params[0].Compute(Data[idx.x]);
+}
+#endif // __PRUNE_HLSL__
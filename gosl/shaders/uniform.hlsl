@@ -0,0 +1,36 @@
+#ifndef __UNIFORM_HLSL__
+#define __UNIFORM_HLSL__
+
+
+// UniParams are bound as a uniform buffer, where the
+// array elements have a 16 byte stride in std140
+struct UniParams {
+
+	// gains for each pathway
+	float Gains[4];
+};
+
+// LayParams are marked for std140 checking via directive
+struct LayParams {
+
+	// rate constant
+	float Tau;
+
+	float pad, pad1, pad2;
+};
+
+// StoreData is bound as a storage buffer
+struct StoreData {
+
+	// values
+	float Vals[4];
+};
+
+[[vk::binding(0, 0)]] ConstantBuffer<UniParams> Params;
+[[vk::binding(0, 1)]] StructuredBuffer<LayParams> Lays;
+[[vk::binding(0, 2)]] RWStructuredBuffer<StoreData> Data;
+[numthreads(1, 1, 1)]
+void main(uint3 idx : SV_DispatchThreadID) {
+    Data[idx.x].Vals[0] = Params.Gains[0] * Lays[0].Tau;
+}
+#endif // __UNIFORM_HLSL__
@@ -0,0 +1,51 @@
+#ifndef __VECTORS_HLSL__
+#define __VECTORS_HLSL__
+
+
+// Particle has vector and matrix fields
+struct Particle {
+
+	// position
+	float4 Pos;
+
+	// velocity
+	float3 Vel;
+
+	// mass
+	float Mass;
+
+	// grid cell index
+	int4 Cell;
+
+	// flags
+	uint4 Flags;
+
+	// rotation
+	float4x4 Rot;
+	void Step(float dt, float3 force) {
+		float3 acc = (force / this.Mass);
+		this.Vel += (acc * dt);
+		this.Vel = min(this.Vel, float3(10, 10, 10));
+		float3 up = float3(0, 0, 1);
+		float3 side = normalize(cross(this.Vel, up));
+		float speed = length(this.Vel);
+		this.Pos.x += speed * side.x;
+		this.Pos = mul(this.Rot, (this.Pos + float4(dt, dt, dt, 0)));
+		this.Cell = max((int4(1, 2, 3, 4) * this.Cell), int4(0, 0, 0, 0));
+		this.Flags = uint4(1, 2, 3, this.Flags.w);
+		int3 ci = int3(this.Cell.x, this.Cell.y, 1);
+		int3 cx = int3(1, 0, 0);
+		this.Cell.z = dot((int3(ci.y*cx.z - ci.z*cx.y, ci.z*cx.x - ci.x*cx.z, ci.x*cx.y - ci.y*cx.x) + ci), ci);
+		uint3 fu = uint3(1, 2, 3);
+		uint3 fz = uint3(0, 0, 1);
+		this.Flags.x = uint3(fu.y*fz.z - fu.z*fz.y, fu.z*fz.x - fu.x*fz.z, fu.x*fz.y - fu.y*fz.x).x;
+		float nd = dot(this.Pos, this.Pos) + dot(this.Vel, this.Vel);
+		float4x4 rot = transpose(float4x4(this.Pos, (-this.Pos), (float4)0, (float4)0));
+		this.Rot = (mul(this.Rot, transpose(rot)) * nd);
+		this.Pos.y = this.Rot._m01_m11_m21_m31.z;
+		this.Rot._m03_m13_m23_m33 = (this.Pos * nd);
+	}
+
+};
+
+#endif // __VECTORS_HLSL__
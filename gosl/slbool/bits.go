@@ -0,0 +1,38 @@
+// Copyright (c) 2024, Cogent Core. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package slbool
+
+import "math/bits"
+
+// Bits32 is an HLSL friendly set of 32 boolean flags packed into
+// a uint32, using 1 bit per flag instead of the 32 bits of [Bool].
+// gosl translates its methods into HLSL bit operations.
+type Bits32 uint32
+
+// Get returns whether the bit at given index (0-31) is set
+func (b Bits32) Get(i uint32) bool {
+	return b&(1<<i) != 0
+}
+
+// Set sets the bit at given index (0-31)
+func (b *Bits32) Set(i uint32) {
+	*b |= 1 << i
+}
+
+// Clear clears the bit at given index (0-31)
+func (b *Bits32) Clear(i uint32) {
+	*b &^= 1 << i
+}
+
+// Toggle toggles the bit at given index (0-31)
+func (b *Bits32) Toggle(i uint32) {
+	*b ^= 1 << i
+}
+
+// Count returns the number of bits that are set (popcount),
+// which is the HLSL countbits function
+func (b Bits32) Count() uint32 {
+	return uint32(bits.OnesCount32(uint32(b)))
+}
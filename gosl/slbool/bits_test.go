@@ -0,0 +1,47 @@
+// Copyright (c) 2024, Cogent Core. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package slbool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBits32(t *testing.T) {
+	var b Bits32
+	assert.False(t, b.Get(0))
+	assert.Equal(t, uint32(0), b.Count())
+
+	b.Set(0)
+	b.Set(5)
+	b.Set(31)
+	assert.True(t, b.Get(0))
+	assert.True(t, b.Get(5))
+	assert.True(t, b.Get(31))
+	assert.False(t, b.Get(1))
+	assert.Equal(t, Bits32(1|1<<5|1<<31), b)
+	assert.Equal(t, uint32(3), b.Count())
+
+	b.Set(5) // already set
+	assert.Equal(t, uint32(3), b.Count())
+
+	b.Clear(5)
+	assert.False(t, b.Get(5))
+	b.Clear(6) // already clear
+	assert.Equal(t, Bits32(1|1<<31), b)
+
+	b.Toggle(31)
+	b.Toggle(7)
+	assert.False(t, b.Get(31))
+	assert.True(t, b.Get(7))
+	assert.Equal(t, uint32(2), b.Count())
+
+	for i := uint32(0); i < 32; i++ {
+		b.Set(i)
+	}
+	assert.Equal(t, Bits32(0xffffffff), b)
+	assert.Equal(t, uint32(32), b.Count())
+}
@@ -150,6 +150,17 @@ var Replaces = []Replace{
 	{[]byte("slrand."), []byte("Rand")},
 	{[]byte("sltype.U"), []byte("u")},
 	{[]byte("sltype.F"), []byte("f")},
+	{[]byte("sltype.I"), []byte("i")},
+	{[]byte("math32.Vector2i"), []byte("int2")},
+	{[]byte("math32.Vector3i"), []byte("int3")},
+	{[]byte("math32.Vector2"), []byte("float2")},
+	{[]byte("math32.Vector3"), []byte("float3")},
+	{[]byte("math32.Vector4"), []byte("float4")},
+	{[]byte("math32.Vec2i("), []byte("int2(")},
+	{[]byte("math32.Vec3i("), []byte("int3(")},
+	{[]byte("math32.Vec2("), []byte("float2(")},
+	{[]byte("math32.Vec3("), []byte("float3(")},
+	{[]byte("math32.Vec4("), []byte("float4(")},
 	{[]byte(".SetFromVector2("), []byte("=(")},
 	{[]byte(".SetFrom2("), []byte("=(")},
 	{[]byte(".IsTrue()"), []byte("==1")},
@@ -157,6 +168,7 @@ var Replaces = []Replace{
 	{[]byte(".SetBool(true)"), []byte("=1")},
 	{[]byte(".SetBool(false)"), []byte("=0")},
 	{[]byte(".SetBool("), []byte("=int(")},
+	{[]byte("slbool.Bits32"), []byte("uint")},
 	{[]byte("slbool.Bool"), []byte("int")},
 	{[]byte("slbool.True"), []byte("1")},
 	{[]byte("slbool.False"), []byte("0")},
@@ -0,0 +1,74 @@
+// Copyright (c) 2024, Cogent Core. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package slprint
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+// gosl: translation of the slbool.Bits32 methods into HLSL bit operations.
+
+const slboolPath = "github.com/tomas-mraz/vgpu/gosl/slbool"
+
+// bitsCall prints a method call on an slbool.Bits32 as the equivalent
+// HLSL bit operation, returning false if it is not such a call.
+func (p *printer) bitsCall(x *ast.CallExpr, depth int) bool {
+	if p.pkg == nil {
+		return false
+	}
+	sel, ok := x.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	sl, ok := p.pkg.TypesInfo.Selections[sel]
+	if !ok || sl.Kind() != types.MethodVal {
+		return false
+	}
+	rt := sl.Recv()
+	if pt, ok := rt.(*types.Pointer); ok {
+		rt = pt.Elem()
+	}
+	nt, ok := rt.(*types.Named)
+	if !ok || nt.Obj().Pkg() == nil || nt.Obj().Pkg().Path() != slboolPath || nt.Obj().Name() != "Bits32" {
+		return false
+	}
+	recv := func() {
+		p.expr1(sel.X, token.HighestPrec, depth)
+	}
+	bit := func() {
+		p.print(token.LPAREN, "1u", blank, token.SHL, blank)
+		p.expr1(x.Args[0], token.HighestPrec, depth+1)
+		p.print(token.RPAREN)
+	}
+	switch sel.Sel.Name {
+	case "Get":
+		p.print(token.LPAREN, token.LPAREN)
+		recv()
+		p.print(blank, token.AND, blank)
+		bit()
+		p.print(token.RPAREN, blank, token.NEQ, blank, "0", token.RPAREN)
+	case "Set":
+		recv()
+		p.print(blank, token.OR_ASSIGN, blank)
+		bit()
+	case "Clear":
+		recv()
+		p.print(blank, token.AND_ASSIGN, blank, "~")
+		bit()
+	case "Toggle":
+		recv()
+		p.print(blank, token.XOR_ASSIGN, blank)
+		bit()
+	case "Count":
+		p.print("countbits", token.LPAREN)
+		recv()
+		p.print(token.RPAREN)
+	default:
+		return false
+	}
+	return true
+}
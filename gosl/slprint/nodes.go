@@ -126,6 +126,80 @@ func (p *printer) identList(list []*ast.Ident, indent bool) {
 	p.exprList(token.NoPos, xlist, 1, mode, token.NoPos, false)
 }
 
+// gosl: arrayDims splits a (possibly nested) fixed-size array type
+// into its innermost element type and the list of length expressions,
+// outermost first, so it can be printed in C order: elt name[d0][d1].
+// Non-array types are returned as-is with no dims.
+func arrayDims(x ast.Expr) (ast.Expr, []ast.Expr) {
+	var dims []ast.Expr
+	for {
+		at, ok := x.(*ast.ArrayType)
+		if !ok || at.Len == nil {
+			return x, dims
+		}
+		dims = append(dims, at.Len)
+		x = at.Elt
+	}
+}
+
+// gosl: declNames prints a list of declared names, each followed by
+// the given array dims, as required for C-style array declarations.
+func (p *printer) declNames(list []*ast.Ident, dims []ast.Expr, indent bool) {
+	if len(dims) == 0 {
+		p.identList(list, indent)
+		return
+	}
+	for i, nm := range list {
+		if i > 0 {
+			p.print(token.COMMA, blank)
+		}
+		p.expr(nm)
+		for _, d := range dims {
+			p.print(token.LBRACK)
+			p.expr(d)
+			p.print(token.RBRACK)
+		}
+	}
+}
+
+// gosl: typeDims returns the name of the innermost element type and
+// the C-style dims suffix (e.g., "[4][2]") for a type-checked type,
+// used when the type is only known from type inference.
+func typeDims(typ types.Type) (string, string) {
+	dims := ""
+	for {
+		at, ok := typ.(*types.Array)
+		if !ok {
+			break
+		}
+		dims += fmt.Sprintf("[%d]", at.Len())
+		typ = at.Elem()
+	}
+	_, nm := filepath.Split(typ.String()) // get rid of any paths
+	return nm, dims
+}
+
+// gosl: zeroArrayLit prints an explicit zero initializer list for an
+// empty array composite literal, because HLSL does not allow an empty {}.
+func (p *printer) zeroArrayLit(at *types.Array) {
+	p.print(token.LBRACE)
+	for i := int64(0); i < at.Len(); i++ {
+		if i > 0 {
+			p.print(token.COMMA, blank)
+		}
+		switch et := at.Elem().Underlying().(type) {
+		case *types.Array:
+			p.zeroArrayLit(et)
+		case *types.Basic:
+			p.print("0")
+		default:
+			nm, _ := typeDims(at.Elem())
+			p.print(token.LPAREN, nm, token.RPAREN, "0")
+		}
+	}
+	p.print(token.RBRACE)
+}
+
 const filteredMsg = "contains filtered or unexported fields"
 
 // Print a list of expressions. If the list spans multiple
@@ -370,18 +444,22 @@ func (p *printer) parameters(fields *ast.FieldList, mode paramMode) {
 				p.print(blank)
 			}
 			// parameter type -- gosl = type first, replace ptr star with `inout`
-			p.expr(p.inoutPtr(stripParensAlways(par.Type)))
+			elt, dims := arrayDims(p.inoutPtr(stripParensAlways(par.Type)))
+			p.expr(elt)
 			p.print(blank)
 			// parameter names
 			if len(par.Names) > 1 {
 				for ni, nm := range par.Names {
 					if ni > 0 {
 						p.print(token.COMMA, blank)
-						p.expr(p.inoutPtr(stripParensAlways(par.Type)))
+						elt, _ = arrayDims(p.inoutPtr(stripParensAlways(par.Type)))
+						p.expr(elt)
 						p.print(blank)
 					}
-					p.expr0(nm, 1)
+					p.declNames([]*ast.Ident{nm}, dims, false)
 				}
+			} else if len(dims) > 0 {
+				p.declNames(par.Names, dims, false)
 			} else {
 				// Very subtle: If we indented before (ws == ignore), identList
 				// won't indent again. If we didn't (ws == indent), identList will
@@ -559,17 +637,15 @@ func (p *printer) fieldList(fields *ast.FieldList, isStruct, isIncomplete bool)
 			p.print(lbrace, token.LBRACE, blank)
 			f := list[0]
 			if isStruct {
-				for i, x := range f.Names {
-					if i > 0 {
-						// no comments so no need for comma position
-						p.print(token.COMMA, blank)
-					}
-					p.expr(x)
-				}
+				p.noNarrow = true
+				defer func() { p.noNarrow = false }()
+				// gosl: C ordering, type first
+				elt, dims := arrayDims(f.Type)
+				p.expr(elt)
 				if len(f.Names) > 0 {
 					p.print(blank)
 				}
-				p.expr(f.Type)
+				p.declNames(f.Names, dims, false)
 			} else { // interface
 				if len(f.Names) > 0 {
 					name := f.Names[0] // method name
@@ -592,6 +668,8 @@ func (p *printer) fieldList(fields *ast.FieldList, isStruct, isIncomplete bool)
 	}
 
 	if isStruct {
+		p.noNarrow = true
+		defer func() { p.noNarrow = false }()
 
 		sep := vtab
 		if len(list) == 1 {
@@ -607,9 +685,10 @@ func (p *printer) fieldList(fields *ast.FieldList, isStruct, isIncomplete bool)
 			p.recordLine(&line)
 			if len(f.Names) > 0 {
 				// named fields
-				p.expr(f.Type)
+				elt, dims := arrayDims(f.Type)
+				p.expr(elt)
 				p.print(sep)
-				p.identList(f.Names, false)
+				p.declNames(f.Names, dims, false)
 				extraTabs = 1
 			} else {
 				// anonymous field
@@ -1017,6 +1096,10 @@ func (p *printer) expr1(expr ast.Expr, prec1, depth int) {
 		if len(x.Args) > 1 {
 			depth++
 		}
+		if p.vecCall(x, depth) || p.bitsCall(x, depth) {
+			break
+		}
+		p.mathCall(x)
 		var wasIndented bool
 		if _, ok := x.Fun.(*ast.FuncType); ok {
 			// conversions to literal function types require parentheses around the type
@@ -1045,8 +1128,18 @@ func (p *printer) expr1(expr ast.Expr, prec1, depth int) {
 		}
 
 	case *ast.CompositeLit:
-		// composite literal elements that are composite literals themselves may have the type omitted
-		if x.Type != nil {
+		if p.vecLit(x, depth) {
+			break
+		}
+		// gosl: array literals are C initializer lists, without the type
+		if _, isArray := x.Type.(*ast.ArrayType); isArray || x.Type == nil {
+			if tv, has := p.pkg.TypesInfo.Types[x]; has && len(x.Elts) == 0 {
+				if at, ok := tv.Type.Underlying().(*types.Array); ok {
+					p.zeroArrayLit(at)
+					break
+				}
+			}
+		} else {
 			p.expr1(x.Type, token.HighestPrec, depth)
 		}
 		p.level++
@@ -1216,6 +1309,9 @@ func (p *printer) possibleSelectorExpr(expr ast.Expr, prec1, depth int) bool {
 // selectorExpr handles an *ast.SelectorExpr node and reports whether x spans
 // multiple lines.
 func (p *printer) selectorExpr(x *ast.SelectorExpr, depth int, isMethod bool) bool {
+	if !isMethod && p.vecField(x, depth) {
+		return false
+	}
 	// gosl: replace receiver with this.
 	if id, ok := x.X.(*ast.Ident); ok && p.curFuncRecv != nil && id.Name == p.curFuncRecv.Name {
 		p.print("this")
@@ -1522,14 +1618,21 @@ func (p *printer) stmt(stmt ast.Stmt, nextIsRBrace, nosemi bool) {
 		}
 		if s.Tok == token.DEFINE && len(s.Lhs) == 1 {
 			if lid, isId := s.Lhs[0].(*ast.Ident); isId {
+				dims := ""
 				if def, has := p.pkg.TypesInfo.Defs[lid]; has {
 					// fmt.Println(def)
-					nm := def.Type().String()
-					_, nm = filepath.Split(nm) // get rid of any paths
+					var nm string
+					nm, dims = typeDims(def.Type())
+					if dims == "" {
+						nm = p.narrowType(def.Type(), nm, lid.Pos(), "float64 local: "+lid.Name)
+					}
 					// fmt.Println(nm)
 					p.print(nm, blank)
 				}
 				p.exprList(s.Pos(), s.Lhs, depth, 0, s.TokPos, false)
+				if dims != "" {
+					p.print(dims)
+				}
 			} else {
 				p.exprList(s.Pos(), s.Lhs, depth, 0, s.TokPos, false)
 			}
@@ -1759,13 +1862,18 @@ func (p *printer) valueSpec(s *ast.ValueSpec, keepType bool, tok token.Token, fi
 	case token.TYPE:
 		p.print(s.Pos(), "typedef", blank)
 	}
+	var dims []ast.Expr
 	if s.Type != nil {
-		p.expr(s.Type)
+		var elt ast.Expr
+		elt, dims = arrayDims(s.Type)
+		p.expr(elt)
 	} else if tok == token.CONST && firstSpec.Type != nil {
 		p.expr(firstSpec.Type)
+	} else if tok == token.CONST {
+		p.untypedConst(s)
 	}
 	p.print(vtab)
-	p.identList(s.Names, false) // always present
+	p.declNames(s.Names, dims, false) // always present
 	if isIota {
 		p.print(vtab, token.ASSIGN, blank)
 		p.print(fmt.Sprintf("%d", idx))
@@ -1783,6 +1891,23 @@ func (p *printer) valueSpec(s *ast.ValueSpec, keepType bool, tok token.Token, fi
 	}
 }
 
+// gosl: untypedConst prints float32 as the type for an untyped float
+// constant in F32 mode, returning true if so.
+func (p *printer) untypedConst(s *ast.ValueSpec) bool {
+	if !p.F32 || len(s.Names) == 0 {
+		return false
+	}
+	def, has := p.pkg.TypesInfo.Defs[s.Names[0]]
+	if !has || def == nil {
+		return false
+	}
+	if nm := p.narrowType(def.Type(), "", s.Pos(), "float64 constant: "+s.Names[0].Name); nm != "" {
+		p.print(nm)
+		return true
+	}
+	return false
+}
+
 func sanitizeImportPath(lit *ast.BasicLit) *ast.BasicLit {
 	// Note: An unmodified AST generated by go/parser will already
 	// contain a backward- or double-quoted path string that does
@@ -1851,11 +1976,16 @@ func (p *printer) spec(spec ast.Spec, n int, doIndent bool, tok token.Token) {
 		} else {
 			p.print(s.Pos(), ignore)
 		}
+		var dims []ast.Expr
 		if s.Type != nil {
-			p.expr(s.Type)
+			var elt ast.Expr
+			elt, dims = arrayDims(s.Type)
+			p.expr(elt)
+			p.print(blank)
+		} else if tok == token.CONST && p.untypedConst(s) {
 			p.print(blank)
 		}
-		p.identList(s.Names, doIndent) // always present
+		p.declNames(s.Names, dims, doIndent) // always present
 		if s.Values != nil {
 			p.print(blank, token.ASSIGN, blank)
 			p.exprList(token.NoPos, s.Values, 1, 0, token.NoPos, false)
@@ -1982,7 +2112,7 @@ func (p *printer) nodeSize(n ast.Node, maxSize int) (size int) {
 	// nodeSize computation must be independent of particular
 	// style so that we always get the same decision; print
 	// in RawFormat
-	cfg := Config{Mode: RawFormat}
+	cfg := Config{Mode: RawFormat, Renames: p.Renames, F32: p.F32}
 	var buf bytes.Buffer
 	if err := cfg.fprint(&buf, p.pkg, p.pos, n, p.nodeSizes); err != nil {
 		return
@@ -2059,7 +2189,7 @@ func (p *printer) funcBody(headerSize int, sep whiteSpace, b *ast.BlockStmt) {
 			p.print(blank)
 			for i, s := range b.List {
 				if i > 0 {
-					p.print(token.SEMICOLON, blank)
+					p.print(blank) // stmt generates its own semi
 				}
 				p.stmt(s, i == len(b.List)-1, false)
 			}
@@ -2090,13 +2220,91 @@ func (p *printer) methRecvType(typ ast.Expr) string {
 	case *ast.StarExpr:
 		return p.methRecvType(x.X)
 	case *ast.Ident:
-		return x.Name
+		return p.identName(x)
 	default:
 		return fmt.Sprintf("recv type unknown: %+T", x)
 	}
 	return ""
 }
 
+// gosl: identName returns the name to print for given identifier,
+// which is the //gosl:name directive name if the object it
+// defines or uses has been renamed.
+// In F32 mode, float64 is narrowed to float32, and the math package
+// is replaced with math32.
+func (p *printer) identName(id *ast.Ident) string {
+	if p.pkg == nil || (len(p.Renames) == 0 && !p.F32) {
+		return id.Name
+	}
+	ob := p.pkg.TypesInfo.Defs[id]
+	if ob == nil {
+		ob = p.pkg.TypesInfo.Uses[id]
+	}
+	if nm, has := p.Renames[ob]; has {
+		return nm
+	}
+	if p.F32 && !p.noNarrow {
+		if ob == types.Universe.Lookup("float64") {
+			p.narrowed(id.Pos(), "float64 type")
+			return "float32"
+		}
+		if pn, ok := ob.(*types.PkgName); ok && pn.Imported().Path() == "math" {
+			return "math32"
+		}
+	}
+	return id.Name
+}
+
+// gosl: narrowed records a float64 to float32 narrowing site in F32 mode.
+// Each site is only recorded once, even if it is printed more than once,
+// as for the type of parameters that share it (e.g., v, dt float64).
+func (p *printer) narrowed(pos token.Pos, what string) {
+	if p.Narrowings == nil || p.narrowedAt[pos] {
+		return
+	}
+	if p.narrowedAt == nil {
+		p.narrowedAt = map[token.Pos]bool{}
+	}
+	p.narrowedAt[pos] = true
+	*p.Narrowings = append(*p.Narrowings, Narrowing{Pos: p.pkg.Fset.PositionFor(pos, true), What: what})
+}
+
+// gosl: narrowType returns float32 for float64 (and untyped float)
+// types in F32 mode, recording the narrowing of what at pos.
+// Otherwise returns nm as-is.
+func (p *printer) narrowType(typ types.Type, nm string, pos token.Pos, what string) string {
+	if !p.F32 {
+		return nm
+	}
+	bt, ok := typ.(*types.Basic)
+	if !ok || (bt.Kind() != types.Float64 && bt.Kind() != types.UntypedFloat) {
+		return nm
+	}
+	p.narrowed(pos, what)
+	return "float32"
+}
+
+// gosl: mathCall records a call to a float64 math package function in F32
+// mode, which is translated into the float32 version.
+func (p *printer) mathCall(x *ast.CallExpr) {
+	if !p.F32 {
+		return
+	}
+	sel, ok := x.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+	pid, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return
+	}
+	pn, ok := p.pkg.TypesInfo.Uses[pid].(*types.PkgName)
+	if !ok || pn.Imported().Path() != "math" {
+		return
+	}
+	p.narrowed(x.Pos(), "math call: math."+sel.Sel.Name)
+}
+
 func (p *printer) funcDecl(d *ast.FuncDecl) {
 	p.setComment(d.Doc)
 	// We have to save startCol only after emitting FUNC; otherwise it can be on a
@@ -2104,10 +2312,8 @@ func (p *printer) funcDecl(d *ast.FuncDecl) {
 	// FUNC is emitted).
 	startCol := p.out.Column - len("func ")
 	if d.Recv != nil {
-		for ex := range p.ExcludeFunctions {
-			if d.Name.Name == ex {
-				return
-			}
+		if p.ExcludeFunctions[d.Name.Name] && !p.Keep[p.pkg.TypesInfo.Defs[d.Name]] {
+			return
 		}
 		if d.Recv.List[0].Names != nil {
 			p.curFuncRecv = d.Recv.List[0].Names[0]
@@ -2124,6 +2330,7 @@ func (p *printer) funcDecl(d *ast.FuncDecl) {
 		p.print(d.Pos(), ignore)
 		p.print(indent)
 		// p.parameters(d.Recv, funcParam) // method: print receiver
+		startCol = 0 // header starts on a new line after the method tag and comments
 	} else {
 		p.print(d.Pos(), ignore) // trigger emission of comments!
 	}
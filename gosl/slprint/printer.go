@@ -16,6 +16,7 @@ import (
 	"go/ast"
 	"go/build/constraint"
 	"go/token"
+	"go/types"
 	"io"
 	"os"
 	"strings"
@@ -103,6 +104,9 @@ type printer struct {
 	cachedLine int // line corresponding to cachedPos
 
 	curFuncRecv *ast.Ident // current function receiver
+	noNarrow    bool       // do not narrow float64 in F32 mode (struct fields)
+
+	narrowedAt map[token.Pos]bool // narrowing sites already recorded
 }
 
 func (p *printer) init(cfg *Config, pkg *packages.Package, pos token.Position, nodeSizes map[ast.Node]int) {
@@ -930,7 +934,7 @@ func (p *printer) print(args ...any) {
 			continue
 
 		case *ast.Ident:
-			data = x.Name
+			data = p.identName(x)
 			impliedSemi = true
 			p.lastTok = token.IDENT
 
@@ -1305,6 +1309,37 @@ type Config struct {
 	Tabwidth         int  // default: 8
 	Indent           int  // default: 0 (all code is indented at least by this much)
 	ExcludeFunctions map[string]bool
+
+	// Keep are the methods with a //gosl:keep directive, which are
+	// included even if their name is in ExcludeFunctions.
+	Keep map[types.Object]bool
+
+	// Renames are the HLSL names for objects renamed via
+	// //gosl:name directives, used for their declarations and all uses.
+	Renames map[types.Object]string
+
+	// F32 narrows float64 types, constants and math calls to float32,
+	// except for struct fields, which must match the Go memory layout.
+	F32 bool
+
+	// Narrowings, if non-nil, accumulates the sites where float64
+	// was narrowed to float32 in F32 mode.
+	Narrowings *[]Narrowing
+}
+
+// Narrowing records a site in the Go source where a float64 value
+// was narrowed to float32 in F32 mode.
+type Narrowing struct {
+
+	// position in the original Go source
+	Pos token.Position
+
+	// description of what was narrowed
+	What string
+}
+
+func (n Narrowing) String() string {
+	return n.Pos.String() + ": " + n.What
 }
 
 // fprint implements Fprint and takes a nodesSizes map for setting up the printer state.
@@ -0,0 +1,295 @@
+// Copyright (c) 2024, Cogent Core. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package slprint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+)
+
+// gosl: translation of the sltype and math32 vector and matrix types,
+// and their methods, into native HLSL types and operators.
+
+const (
+	sltypePath = "github.com/tomas-mraz/vgpu/gosl/sltype"
+	math32Path = "cogentcore.org/core/math32"
+)
+
+// vecTypes are the HLSL names of the vector and matrix types
+// defined in the sltype and math32 packages, by Go type name.
+// The sltype Float types are aliases to the math32 Vector types.
+var vecTypes = map[string]string{
+	"Int2":     "int2",
+	"Int3":     "int3",
+	"Int4":     "int4",
+	"Uint2":    "uint2",
+	"Uint3":    "uint3",
+	"Uint4":    "uint4",
+	"Vector2":  "float2",
+	"Vector3":  "float3",
+	"Vector4":  "float4",
+	"Vector2i": "int2",
+	"Vector3i": "int3",
+	"Float2x2": "float2x2",
+	"Float3x3": "float3x3",
+	"Float4x4": "float4x4",
+}
+
+// vecOps are the HLSL operators for the vector and matrix arithmetic
+// methods, and their assignment versions for the Set methods.
+var vecOps = map[string]token.Token{
+	"Add":          token.ADD,
+	"AddScalar":    token.ADD,
+	"Sub":          token.SUB,
+	"SubScalar":    token.SUB,
+	"Mul":          token.MUL,
+	"MulScalar":    token.MUL,
+	"Div":          token.QUO,
+	"DivScalar":    token.QUO,
+	"SetAdd":       token.ADD_ASSIGN,
+	"SetAddScalar": token.ADD_ASSIGN,
+	"SetSub":       token.SUB_ASSIGN,
+	"SetSubScalar": token.SUB_ASSIGN,
+	"SetMul":       token.MUL_ASSIGN,
+	"SetMulScalar": token.MUL_ASSIGN,
+	"SetDiv":       token.QUO_ASSIGN,
+	"SetDivScalar": token.QUO_ASSIGN,
+}
+
+// vecFuncs are the HLSL intrinsic functions for the vector and matrix
+// methods, which take the receiver as the first argument.
+var vecFuncs = map[string]string{
+	"Dot":       "dot",
+	"Cross":     "cross",
+	"Length":    "length",
+	"Normal":    "normalize",
+	"Min":       "min",
+	"Max":       "max",
+	"Abs":       "abs",
+	"Floor":     "floor",
+	"Ceil":      "ceil",
+	"Transpose": "transpose",
+	"MulVector": "mul",
+}
+
+// vecType returns the HLSL name of the given type if it is one of
+// the vector or matrix types in [vecTypes] (or a pointer to one),
+// along with its dimension and whether it is a matrix.
+func vecType(tp types.Type) (hlsl string, dim int, isMatrix bool) {
+	if pt, ok := tp.(*types.Pointer); ok {
+		tp = pt.Elem()
+	}
+	nt, ok := types.Unalias(tp).(*types.Named)
+	if !ok {
+		return
+	}
+	ob := nt.Obj()
+	if ob.Pkg() == nil || (ob.Pkg().Path() != sltypePath && ob.Pkg().Path() != math32Path) {
+		return
+	}
+	hlsl, ok = vecTypes[ob.Name()]
+	if !ok {
+		return
+	}
+	isMatrix = strings.Contains(hlsl, "x")
+	dim = int(hlsl[len(hlsl)-1] - '0')
+	return
+}
+
+// vecCall prints a method call on a vector or matrix type as the
+// equivalent HLSL operator or intrinsic function, returning false
+// if it is not such a call.
+func (p *printer) vecCall(x *ast.CallExpr, depth int) bool {
+	if p.pkg == nil {
+		return false
+	}
+	sel, ok := x.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	sl, ok := p.pkg.TypesInfo.Selections[sel]
+	if !ok || sl.Kind() != types.MethodVal {
+		return false
+	}
+	hlsl, _, isMatrix := vecType(sl.Recv())
+	if hlsl == "" {
+		return false
+	}
+	mnm := sel.Sel.Name
+	recv := func() {
+		p.expr1(sel.X, token.HighestPrec, depth)
+	}
+	fun := func(fn string, args ...ast.Expr) {
+		p.print(fn, token.LPAREN)
+		recv()
+		for _, a := range args {
+			p.print(token.COMMA, blank)
+			p.expr0(a, depth+1)
+		}
+		p.print(token.RPAREN)
+	}
+	assign := func() {
+		recv()
+		p.print(blank, token.ASSIGN, blank)
+	}
+	switch {
+	case isMatrix && mnm == "Mul":
+		fun("mul", x.Args...)
+	case mnm == "Cross" && hlsl != "float3":
+		p.intCross(hlsl, sel.X, x.Args[0], depth)
+	case vecOps[mnm] != token.ILLEGAL:
+		op := vecOps[mnm]
+		if strings.HasPrefix(mnm, "Set") {
+			recv()
+			p.print(blank, op, blank)
+			p.expr0(x.Args[0], depth+1)
+			break
+		}
+		p.print(token.LPAREN)
+		recv()
+		p.print(blank, op, blank)
+		p.expr1(x.Args[0], token.HighestPrec, depth+1)
+		p.print(token.RPAREN)
+	case vecFuncs[mnm] != "":
+		fun(vecFuncs[mnm], x.Args...)
+	case mnm == "Negate":
+		p.print(token.LPAREN, token.SUB)
+		recv()
+		p.print(token.RPAREN)
+	case mnm == "LengthSquared":
+		fun("dot", sel.X)
+	case mnm == "SetNormal":
+		assign()
+		fun("normalize")
+	case mnm == "SetMin" || mnm == "SetMax":
+		assign()
+		fun(strings.ToLower(mnm[3:]), x.Args...)
+	case mnm == "SetZero":
+		assign()
+		p.print(token.LPAREN, hlsl, token.RPAREN, "0")
+	case mnm == "Set" && !isMatrix:
+		assign()
+		p.print(hlsl, token.LPAREN)
+		p.exprList(x.Lparen, x.Args, depth, commaTerm, x.Rparen, false)
+		p.print(token.RPAREN)
+	default:
+		return false
+	}
+	return true
+}
+
+// intCross prints the cross product of the given integer vectors as
+// an explicit constructor of the given HLSL type, as the HLSL cross
+// intrinsic only accepts float3.
+func (p *printer) intCross(hlsl string, a, b ast.Expr, depth int) {
+	comp := func(e ast.Expr, c byte) {
+		p.expr1(e, token.HighestPrec, depth+1)
+		p.print(token.PERIOD, string(c))
+	}
+	p.print(hlsl, token.LPAREN)
+	for i, cs := range []string{"yzzy", "zxxz", "xyyx"} {
+		if i > 0 {
+			p.print(token.COMMA, blank)
+		}
+		comp(a, cs[0])
+		p.print(token.MUL)
+		comp(b, cs[1])
+		p.print(blank, token.SUB, blank)
+		comp(a, cs[2])
+		p.print(token.MUL)
+		comp(b, cs[3])
+	}
+	p.print(token.RPAREN)
+}
+
+// vecField prints a selector of a vector component (X, Y, Z, W) as the
+// lower case HLSL component, or of a matrix column as the swizzle of its
+// elements (e.g., m._m01_m11_m21 for m.Y), as the HLSL matrix index is the
+// row, which can be assigned as well as read.  Returns false if it is not
+// such a selector.
+func (p *printer) vecField(x *ast.SelectorExpr, depth int) bool {
+	if p.pkg == nil {
+		return false
+	}
+	sl, ok := p.pkg.TypesInfo.Selections[x]
+	if !ok || sl.Kind() != types.FieldVal {
+		return false
+	}
+	hlsl, dim, isMatrix := vecType(sl.Recv())
+	if hlsl == "" || len(x.Sel.Name) != 1 {
+		return false
+	}
+	ci := strings.Index("XYZW"[:dim], x.Sel.Name)
+	if ci < 0 {
+		return false
+	}
+	p.expr1(x.X, token.HighestPrec, depth)
+	if isMatrix {
+		var sw strings.Builder
+		for r := range dim {
+			fmt.Fprintf(&sw, "_m%d%d", r, ci)
+		}
+		p.print(token.PERIOD, x.Sel.Pos(), sw.String())
+		return true
+	}
+	p.print(token.PERIOD, x.Sel.Pos(), strings.ToLower(x.Sel.Name))
+	return true
+}
+
+// vecLit prints a composite literal of a vector type as the HLSL
+// constructor, with any missing components set to 0, and of a matrix
+// type as the transpose of the constructor from the column vectors,
+// as the HLSL constructor takes rows.  Returns false if it is not
+// such a literal.
+func (p *printer) vecLit(x *ast.CompositeLit, depth int) bool {
+	if p.pkg == nil {
+		return false
+	}
+	hlsl, dim, isMatrix := vecType(p.pkg.TypesInfo.TypeOf(x))
+	if hlsl == "" {
+		return false
+	}
+	comps := "XYZW"[:dim]
+	elts := make([]ast.Expr, dim)
+	for i, e := range x.Elts {
+		if kv, ok := e.(*ast.KeyValueExpr); ok {
+			if id, ok := kv.Key.(*ast.Ident); ok {
+				if ci := strings.Index(comps, id.Name); ci >= 0 && len(id.Name) == 1 {
+					elts[ci] = kv.Value
+				}
+			}
+			continue
+		}
+		if i < dim {
+			elts[i] = e
+		}
+	}
+	if isMatrix {
+		p.print("transpose", token.LPAREN)
+	}
+	p.print(hlsl, token.LPAREN)
+	for i, e := range elts {
+		if i > 0 {
+			p.print(token.COMMA, blank)
+		}
+		switch {
+		case e != nil:
+			p.expr0(e, depth+1)
+		case isMatrix:
+			p.print(token.LPAREN, "float"+strconv.Itoa(dim), token.RPAREN, "0")
+		default:
+			p.print("0")
+		}
+	}
+	p.print(token.RPAREN)
+	if isMatrix {
+		p.print(token.RPAREN)
+	}
+	return true
+}
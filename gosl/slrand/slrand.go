@@ -237,6 +237,338 @@ func Uintn(counter *sltype.Uint2, key uint32, n uint32) uint32 {
 	return uint32(v * float32(n))
 }
 
+////////////////////////////////////////////////////////////
+//   Philox4x32-10: 128 bit counter and 64 bit key, producing
+//   four 32 bit values per call.
+
+// Philox4x32round does one round of updating of the counter
+func Philox4x32round(counter *sltype.Uint4, key sltype.Uint2) {
+	lo0, hi0 := MulHiLo64(0xD2511F53, counter.X)
+	lo1, hi1 := MulHiLo64(0xCD9E8D57, counter.Z)
+	counter.X, counter.Y, counter.Z, counter.W = hi1^counter.Y^key.X, lo1, hi0^counter.W^key.Y, lo0
+}
+
+// Philox4x32bumpkey does one round of updating of the key
+func Philox4x32bumpkey(key *sltype.Uint2) {
+	key.X += 0x9E3779B9
+	key.Y += 0xBB67AE85
+}
+
+// Philox4x32 implements the stateless counter-based RNG algorithm
+// returning a random number as 4 uint32 32 bit values, given a
+// 128 bit counter and 64 bit key input that determine the result.
+func Philox4x32(counter sltype.Uint4, key sltype.Uint2) sltype.Uint4 {
+	Philox4x32round(&counter, key) // 1
+	Philox4x32bumpkey(&key)
+	Philox4x32round(&counter, key) // 2
+	Philox4x32bumpkey(&key)
+	Philox4x32round(&counter, key) // 3
+	Philox4x32bumpkey(&key)
+	Philox4x32round(&counter, key) // 4
+	Philox4x32bumpkey(&key)
+	Philox4x32round(&counter, key) // 5
+	Philox4x32bumpkey(&key)
+	Philox4x32round(&counter, key) // 6
+	Philox4x32bumpkey(&key)
+	Philox4x32round(&counter, key) // 7
+	Philox4x32bumpkey(&key)
+	Philox4x32round(&counter, key) // 8
+	Philox4x32bumpkey(&key)
+	Philox4x32round(&counter, key) // 9
+	Philox4x32bumpkey(&key)
+
+	Philox4x32round(&counter, key) // 10
+	return counter
+}
+
+// Uint4ToFloat converts four uint32 32 bit integers (Uint4)
+// into four corresponding 32 bit float values (Float4)
+// in the (0,1) interval (i.e., exclusive of 1).
+func Uint4ToFloat(val sltype.Uint4) sltype.Float4 {
+	var r sltype.Float4
+	r.X = Uint32ToFloat(val.X)
+	r.Y = Uint32ToFloat(val.Y)
+	r.Z = Uint32ToFloat(val.Z)
+	r.W = Uint32ToFloat(val.W)
+	return r
+}
+
+// Uint4ToFloat11 converts four uint32 32 bit integers (Uint4)
+// into four corresponding 32 bit float values (Float4)
+// in the [-1,1] interval (inclusive of -1 and 1, never identically == 0).
+func Uint4ToFloat11(val sltype.Uint4) sltype.Float4 {
+	var r sltype.Float4
+	r.X = Uint32ToFloat11(val.X)
+	r.Y = Uint32ToFloat11(val.Y)
+	r.Z = Uint32ToFloat11(val.Z)
+	r.W = Uint32ToFloat11(val.W)
+	return r
+}
+
+// Counter4Incr increments the given 128 bit counter by 1,
+// with X as the lowest 32 bits, carrying into Y, Z, and W.
+func Counter4Incr(counter *sltype.Uint4) {
+	Counter4Add(counter, 1)
+}
+
+// Counter4Add adds the given increment to the 128 bit counter,
+// with X as the lowest 32 bits, carrying into Y, Z, and W.
+func Counter4Add(counter *sltype.Uint4, inc uint32) {
+	if inc == 0 {
+		return
+	}
+	if counter.X <= 0xffffffff-inc {
+		counter.X += inc
+		return
+	}
+	counter.X = (inc - 1) - (0xffffffff - counter.X)
+	counter.Y++
+	if counter.Y != 0 {
+		return
+	}
+	counter.Z++
+	if counter.Z != 0 {
+		return
+	}
+	counter.W++
+}
+
+// Uint4 returns four uniformly distributed 32 unsigned integers,
+// based on given 128 bit counter and 64 bit key, using Philox4x32.
+// The counter is incremented by 1 (in a 128-bit equivalent manner)
+// as a result of this call, ensuring that the next call will produce
+// the next random number in the sequence.  The key should include the
+// unique index of the element being updated.
+func Uint4(counter *sltype.Uint4, key sltype.Uint2) sltype.Uint4 {
+	res := Philox4x32(*counter, key)
+	Counter4Incr(counter)
+	return res
+}
+
+// Float4 returns four uniformly distributed 32 floats
+// in range (0,1) based on given 128 bit counter and 64 bit key,
+// using Philox4x32.  The counter is incremented by 1.
+func Float4(counter *sltype.Uint4, key sltype.Uint2) sltype.Float4 {
+	return Uint4ToFloat(Uint4(counter, key))
+}
+
+// Float114 returns four uniformly distributed 32 floats
+// in range [-1,1] based on given 128 bit counter and 64 bit key,
+// using Philox4x32.  The counter is incremented by 1.
+func Float114(counter *sltype.Uint4, key sltype.Uint2) sltype.Float4 {
+	return Uint4ToFloat11(Uint4(counter, key))
+}
+
+// NormFloat4 returns four random 32 bit floating numbers
+// distributed according to the normal, Gaussian distribution
+// with zero mean and unit variance, based on given 128 bit counter
+// and 64 bit key, using Philox4x32 and the Box-Muller algorithm
+// on each pair of values.  The counter is incremented by 1.
+func NormFloat4(counter *sltype.Uint4, key sltype.Uint2) sltype.Float4 {
+	ur := Uint4(counter, key)
+	var f sltype.Float4
+	f.X, f.Y = SincosPi(Uint32ToFloat11(ur.X))
+	r := math32.Sqrt(-2. * math32.Log(Uint32ToFloat(ur.Y))) // guaranteed to avoid 0
+	f.X *= r
+	f.Y *= r
+	f.Z, f.W = SincosPi(Uint32ToFloat11(ur.Z))
+	r = math32.Sqrt(-2. * math32.Log(Uint32ToFloat(ur.W)))
+	f.Z *= r
+	f.W *= r
+	return f
+}
+
+////////////////////////////////////////////////////////////
+//   Streams: unique key and counter for each
+//   (seed, stream, item, step), for use with the Philox4x32 functions.
+
+// StreamKey returns the 64 bit Philox4x32 key for given global seed
+// and item index (e.g., the index of the neuron being updated),
+// as {item, seed}.  Every distinct (seed, item) pair has a distinct key.
+func StreamKey(seed, item uint32) sltype.Uint2 {
+	return sltype.Uint2{X: item, Y: seed}
+}
+
+// StreamCounter returns the starting 128 bit Philox4x32 counter for
+// given stream id (e.g., a different use of random numbers within the
+// same item) and step (e.g., the time step or trial), as
+// {0, step, stream, 0}.  The lowest 32 bits (X) are incremented by
+// each call to Uint4, Float4 etc, so each (stream, step) has 2^32
+// calls (2^34 uint32 values) before it would overlap with the next step.
+func StreamCounter(stream, step uint32) sltype.Uint4 {
+	return sltype.Uint4{Y: step, Z: stream}
+}
+
+////////////////////////////////////////////////////////////
+//   Distributions: these consume a variable number of counter
+//   increments (rejection sampling), so the key must be unique
+//   for each element to ensure independent values across elements.
+
+// ExpFloat returns an exponentially distributed 32 bit float
+// in the range (0, +Inf) with rate parameter (lambda) = 1 and mean = 1.
+// To produce a distribution with a different rate parameter,
+// divide by the rate: ExpFloat(counter, key) / rate.
+// Consumes one counter increment.
+func ExpFloat(counter *sltype.Uint2, key uint32) float32 {
+	return -math32.Log(Float(counter, key)) // guaranteed to avoid 0
+}
+
+// GammaFloat returns a random 32 bit float distributed according to
+// the gamma distribution with given shape (alpha, k) > 0 and scale
+// (theta = 1/rate) > 0 parameters, which has mean shape*scale.
+// Uses the Marsaglia-Tsang (2000) rejection method, which accepts
+// over 95% of the time, consuming two counter increments per trial,
+// plus one for shape < 1.
+func GammaFloat(counter *sltype.Uint2, key uint32, shape, scale float32) float32 {
+	if shape < 1 { // boost using G(a) = G(a+1) * U^(1/a)
+		scale *= math32.Pow(Float(counter, key), 1/shape)
+		shape += 1
+	}
+	d := shape - float32(1.0)/3
+	c := 1 / math32.Sqrt(9*d)
+	for {
+		x := NormFloat(counter, key)
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+		u := Float(counter, key)
+		x2 := x * x
+		if u < 1-0.0331*x2*x2 {
+			return d * v * scale
+		}
+		if math32.Log(u) < 0.5*x2+d*(1-v+math32.Log(v)) {
+			return d * v * scale
+		}
+	}
+}
+
+// logFactorials are log(k!) for k = 0..9
+var logFactorials = [10]float32{0, 0, 0.69314718, 1.79175947, 3.17805383, 4.78749174, 6.57925121, 8.52516136, 10.6046029, 12.8018275}
+
+// LogFactorial returns the log of k!, for the non-negative integer
+// value k, using a table for k < 10 and the Stirling series otherwise.
+func LogFactorial(k float32) float32 {
+	if k < 10 {
+		return logFactorials[int32(k)]
+	}
+	const halfLog2Pi = 0.91893853320467274
+	ik := 1 / k
+	return (k+0.5)*math32.Log(k) - k + halfLog2Pi + ik*(float32(1.0)/12-ik*ik*(float32(1.0)/360))
+}
+
+// PoissonUint returns a random uint32 distributed according to
+// the Poisson distribution with given mean lambda >= 0, e.g., the number
+// of spikes in a time interval with an expected number of lambda spikes.
+// For lambda < 10, Knuth's multiplication method is used, which consumes
+// lambda+1 counter increments on average.  Otherwise the transformed
+// rejection method with squeeze of Hörmann (1993) (PTRS) is used,
+// which consumes about 2 counter increments on average.  The float32
+// precision limits the accuracy of the distribution tails for very
+// large lambda (> 1e5).
+func PoissonUint(counter *sltype.Uint2, key uint32, lambda float32) uint32 {
+	if lambda <= 0 {
+		return 0
+	}
+	if lambda < 10 {
+		el := math32.Exp(-lambda)
+		k := uint32(0)
+		p := Float(counter, key)
+		for p > el {
+			k++
+			p *= Float(counter, key)
+		}
+		return k
+	}
+	slam := math32.Sqrt(lambda)
+	loglam := math32.Log(lambda)
+	b := 0.931 + 2.53*slam
+	a := -0.059 + 0.02483*b
+	invalpha := 1.1239 + 1.1328/(b-3.4)
+	vr := 0.9277 - 3.6224/(b-2)
+	for {
+		u := Float(counter, key) - 0.5
+		v := Float(counter, key)
+		us := 0.5 - math32.Abs(u)
+		k := math32.Floor((2*a/us+b)*u + lambda + 0.43)
+		if us >= 0.07 && v <= vr {
+			return uint32(k)
+		}
+		if k < 0 || (us < 0.013 && v > us) {
+			continue
+		}
+		if math32.Log(v)+math32.Log(invalpha)-math32.Log(a/(us*us)+b) <= -lambda+k*loglam-LogFactorial(k) {
+			return uint32(k)
+		}
+	}
+}
+
+// BinomialUint returns a random uint32 distributed according to
+// the binomial distribution: the number of successes in n independent
+// trials each with probability p of success.
+// For n * min(p, 1-p) < 10, the geometric waiting time method is used,
+// which consumes n * min(p, 1-p) + 1 counter increments on average.
+// Otherwise the transformed rejection method with squeeze of
+// Hörmann (1993) (BTRS) is used, which consumes about 2 counter
+// increments on average.  The float32 precision limits the accuracy
+// of the distribution tails for very large n (> 1e5).
+func BinomialUint(counter *sltype.Uint2, key uint32, n uint32, p float32) uint32 {
+	if p <= 0 || n == 0 {
+		return 0
+	}
+	if p >= 1 {
+		return n
+	}
+	if p > 0.5 {
+		return n - binomialLowP(counter, key, n, 1-p)
+	}
+	return binomialLowP(counter, key, n, p)
+}
+
+// binomialLowP is BinomialUint for 0 < p <= 0.5
+func binomialLowP(counter *sltype.Uint2, key uint32, n uint32, p float32) uint32 {
+	fn := float32(n)
+	if fn*p < 10 {
+		lq := math32.Log(1 - p)
+		k := uint32(0)
+		sum := float32(0)
+		for {
+			sum += math32.Ceil(math32.Log(Float(counter, key)) / lq)
+			if sum > fn {
+				return k
+			}
+			k++
+		}
+	}
+	q := 1 - p
+	spq := math32.Sqrt(fn * p * q)
+	b := 1.15 + 2.53*spq
+	a := -0.0873 + 0.0248*b + 0.01*p
+	c := fn*p + 0.5
+	vr := 0.92 - 4.2/b
+	alpha := (2.83 + 5.1/b) * spq
+	lpq := math32.Log(p / q)
+	m := math32.Floor((fn + 1) * p)
+	h := LogFactorial(m) + LogFactorial(fn-m)
+	for {
+		u := Float(counter, key) - 0.5
+		v := Float(counter, key)
+		us := 0.5 - math32.Abs(u)
+		k := math32.Floor((2*a/us+b)*u + c)
+		if k < 0 || k > fn {
+			continue
+		}
+		if us >= 0.07 && v <= vr {
+			return uint32(k)
+		}
+		v = math32.Log(v * alpha / (a/(us*us) + b))
+		if v <= h-LogFactorial(k)-LogFactorial(fn-k)+(k-m)*lpq {
+			return uint32(k)
+		}
+	}
+}
+
 // Counter is used for storing the random counter using aligned 16 byte storage,
 // with convenience methods for typical use cases.
 // It retains a copy of the last Seed value, which is applied to the Hi uint32 value.
@@ -292,3 +624,59 @@ func (ct *Counter) Add(inc uint32) sltype.Uint2 {
 	ct.Set(c)
 	return c
 }
+
+// Counter4 is used for storing the 128 bit random counter for the
+// Philox4x32 functions, using aligned 16 byte storage, with
+// convenience methods for typical use cases, analogous to [Counter].
+// It retains a copy of the last Seed value, which is applied to the
+// upper 64 bits of the counter (Z, W).
+type Counter4 struct {
+
+	// lower 64 bits of counter (X, Y), incremented first
+	Lo sltype.Uint2
+
+	// upper 64 bits of counter (Z, W), incremented only when Lo turns over
+	Hi sltype.Uint2
+
+	// last seed value set by Seed method, restored by Reset()
+	HiSeed sltype.Uint2
+
+	pad, pad1 uint32
+}
+
+// Reset resets counter to last set Seed state
+func (ct *Counter4) Reset() {
+	ct.Lo = sltype.Uint2{}
+	ct.Hi = ct.HiSeed
+}
+
+// Uint4 returns counter as a Uint4
+func (ct *Counter4) Uint4() sltype.Uint4 {
+	return sltype.Uint4{X: ct.Lo.X, Y: ct.Lo.Y, Z: ct.Hi.X, W: ct.Hi.Y}
+}
+
+// Set sets the counter from a Uint4
+func (ct *Counter4) Set(c sltype.Uint4) {
+	ct.Lo = sltype.Uint2{X: c.X, Y: c.Y}
+	ct.Hi = sltype.Uint2{X: c.Z, Y: c.W}
+}
+
+// Seed sets the upper 64 bits of the counter from given seed,
+// saving it in HiSeed field.  Each increment in seed generates a
+// unique sequence of 2^64 numbers.  Resets Lo to 0.
+// This same seed will be restored during Reset
+func (ct *Counter4) Seed(seed uint64) {
+	ct.Lo = sltype.Uint2{}
+	ct.Hi = sltype.Uint2{X: uint32(seed), Y: uint32(seed >> 32)}
+	ct.HiSeed = ct.Hi
+}
+
+// Add increments the counter by given amount.
+// Call this after thread completion with number of random numbers
+// generated per thread.
+func (ct *Counter4) Add(inc uint32) sltype.Uint4 {
+	c := ct.Uint4()
+	Counter4Add(&c, inc)
+	ct.Set(c)
+	return c
+}
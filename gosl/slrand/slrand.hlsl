@@ -149,6 +149,106 @@ void CounterAdd(inout uint2 counter, uint inc) {
 	}
 }
 
+// Philox4x32round does one round of updating of the counter
+void Philox4x32round(inout uint4 counter, uint2 key) {
+	uint hi0;
+	uint lo0;
+	uint hi1;
+	uint lo1;
+	MulHiLo32(0xD2511F53, counter.x, lo0, hi0);
+	MulHiLo32(0xCD9E8D57, counter.z, lo1, hi1);
+	uint4 r;
+	r.x = hi1 ^ counter.y ^ key.x;
+	r.y = lo1;
+	r.z = hi0 ^ counter.w ^ key.y;
+	r.w = lo0;
+	counter = r;
+}
+
+// Philox4x32bumpkey does one round of updating of the key
+void Philox4x32bumpkey(inout uint2 key) {
+	key.x += uint(0x9E3779B9);
+	key.y += uint(0xBB67AE85);
+}
+
+// Philox4x32 implements the stateless counter-based RNG algorithm
+// returning a random number as 4 uint32 32 bit values, given a
+// 128 bit counter and 64 bit key input that determine the result.
+uint4 Philox4x32(uint4 counter, uint2 key) {
+	Philox4x32round(counter, key); // 1
+	Philox4x32bumpkey(key);
+	Philox4x32round(counter, key); // 2
+	Philox4x32bumpkey(key);
+	Philox4x32round(counter, key); // 3
+	Philox4x32bumpkey(key);
+	Philox4x32round(counter, key); // 4
+	Philox4x32bumpkey(key);
+	Philox4x32round(counter, key); // 5
+	Philox4x32bumpkey(key);
+	Philox4x32round(counter, key); // 6
+	Philox4x32bumpkey(key);
+	Philox4x32round(counter, key); // 7
+	Philox4x32bumpkey(key);
+	Philox4x32round(counter, key); // 8
+	Philox4x32bumpkey(key);
+	Philox4x32round(counter, key); // 9
+	Philox4x32bumpkey(key);
+	
+	Philox4x32round(counter, key); // 10
+	return counter;
+}
+
+// Uint4ToFloat converts four uint 32 bit integers (uint4)
+// into four corresponding 32 bit float values (float4)
+// in the (0,1) interval (i.e., exclusive of 1).
+float4 Uint4ToFloat(uint4 val) {
+	float4 r;
+	r.x = UintToFloat(val.x);
+	r.y = UintToFloat(val.y);
+	r.z = UintToFloat(val.z);
+	r.w = UintToFloat(val.w);
+	return r;
+}
+
+// Uint4ToFloat11 converts four uint 32 bit integers into 32 bit floats
+// in the [-1,1] interval (inclusive of -1 and 1, never identically == 0)
+float4 Uint4ToFloat11(uint4 val) {
+	float4 r;
+	r.x = UintToFloat11(val.x);
+	r.y = UintToFloat11(val.y);
+	r.z = UintToFloat11(val.z);
+	r.w = UintToFloat11(val.w);
+	return r;
+}
+
+// Counter4Add adds the given increment to the 128 bit counter,
+// with x as the lowest 32 bits, carrying into y, z, and w.
+void Counter4Add(inout uint4 counter, uint inc) {
+	if(inc == 0) {
+		return;
+	}
+	if(counter.x <= uint(0xffffffff) - inc) {
+		counter.x += inc;
+		return;
+	}
+	counter.x = (inc - 1) - (uint(0xffffffff) - counter.x);
+	counter.y++;
+	if(counter.y != 0) {
+		return;
+	}
+	counter.z++;
+	if(counter.z != 0) {
+		return;
+	}
+	counter.w++;
+}
+
+// Counter4Incr increments the given 128 bit counter by 1,
+// with x as the lowest 32 bits, carrying into y, z, and w.
+void Counter4Incr(inout uint4 counter) {
+	Counter4Add(counter, 1);
+}
+
 ////////////////////////////////////////////////////////////
 //   Methods below provide a standard interface
 //   with more readable names, mapping onto the Go rand methods.
@@ -258,6 +358,247 @@ uint RandUintn(inout uint2 counter, uint key, uint n) {
 	return uint(v * float(n));
 }
 
+////////////////////////////////////////////////////////////
+//   Philox4x32 versions: 128 bit counter and 64 bit key,
+//   producing four 32 bit values per call.
+
+// RandUint4 returns four uniformly distributed 32 unsigned integers,
+// based on given 128 bit counter and 64 bit key, using Philox4x32.
+// The counter is incremented by 1 (in a 128-bit equivalent manner)
+// as a result of this call, ensuring that the next call will produce
+// the next random number in the sequence.  The key should include the
+// unique index of the element being updated.
+uint4 RandUint4(inout uint4 counter, uint2 key) {
+	uint4 res = Philox4x32(counter, key);
+	Counter4Incr(counter);
+	return res;
+}
+
+// RandFloat4 returns four uniformly distributed 32 floats
+// in range (0,1) based on given 128 bit counter and 64 bit key,
+// using Philox4x32.  The counter is incremented by 1.
+float4 RandFloat4(inout uint4 counter, uint2 key) {
+	return Uint4ToFloat(RandUint4(counter, key));
+}
+
+// RandFloat114 returns four uniformly distributed 32 floats
+// in range [-1,1] based on given 128 bit counter and 64 bit key,
+// using Philox4x32.  The counter is incremented by 1.
+float4 RandFloat114(inout uint4 counter, uint2 key) {
+	return Uint4ToFloat11(RandUint4(counter, key));
+}
+
+// RandNormFloat4 returns four random 32 bit floating numbers
+// distributed according to the normal, Gaussian distribution
+// with zero mean and unit variance, based on given 128 bit counter
+// and 64 bit key, using Philox4x32 and the Box-Muller algorithm
+// on each pair of values.  The counter is incremented by 1.
+float4 RandNormFloat4(inout uint4 counter, uint2 key) {
+	uint4 ur = RandUint4(counter, key);
+	float r;
+	float4 f;
+	sincospi(UintToFloat11(ur.x), f.x, f.y);
+	r = sqrt(-2. * log(UintToFloat(ur.y))); // guaranteed to avoid 0.
+	f.x *= r;
+	f.y *= r;
+	sincospi(UintToFloat11(ur.z), f.z, f.w);
+	r = sqrt(-2. * log(UintToFloat(ur.w)));
+	f.z *= r;
+	f.w *= r;
+	return f;
+}
+
+////////////////////////////////////////////////////////////
+//   Streams: unique key and counter for each
+//   (seed, stream, item, step), for use with the Philox4x32 functions.
+
+// RandStreamKey returns the 64 bit Philox4x32 key for given global seed
+// and item index (e.g., the index of the neuron being updated),
+// as {item, seed}.  Every distinct (seed, item) pair has a distinct key.
+uint2 RandStreamKey(uint seed, uint item) {
+	return uint2(item, seed);
+}
+
+// RandStreamCounter returns the starting 128 bit Philox4x32 counter for
+// given stream id (e.g., a different use of random numbers within the
+// same item) and step (e.g., the time step or trial), as
+// {0, step, stream, 0}.  The lowest 32 bits (x) are incremented by
+// each call to RandUint4, RandFloat4 etc, so each (stream, step) has 2^32
+// calls (2^34 uint32 values) before it would overlap with the next step.
+uint4 RandStreamCounter(uint stream, uint step) {
+	return uint4(0, step, stream, 0);
+}
+
+////////////////////////////////////////////////////////////
+//   Distributions: these consume a variable number of counter
+//   increments (rejection sampling), so the key must be unique
+//   for each element to ensure independent values across elements.
+
+// RandExpFloat returns an exponentially distributed 32 bit float
+// in the range (0, +Inf) with rate parameter (lambda) = 1 and mean = 1.
+// To produce a distribution with a different rate parameter,
+// divide by the rate: RandExpFloat(counter, key) / rate.
+// Consumes one counter increment.
+float RandExpFloat(inout uint2 counter, uint key) {
+	return -log(RandFloat(counter, key)); // guaranteed to avoid 0.
+}
+
+// RandGammaFloat returns a random 32 bit float distributed according to
+// the gamma distribution with given shape (alpha, k) > 0 and scale
+// (theta = 1/rate) > 0 parameters, which has mean shape*scale.
+// Uses the Marsaglia-Tsang (2000) rejection method, which accepts
+// over 95% of the time, consuming two counter increments per trial,
+// plus one for shape < 1.
+float RandGammaFloat(inout uint2 counter, uint key, float shape, float scale) {
+	if(shape < 1.0) { // boost using G(a) = G(a+1) * U^(1/a)
+		scale *= pow(RandFloat(counter, key), 1.0 / shape);
+		shape += 1.0;
+	}
+	float d = shape - 1.0 / 3.0;
+	float c = 1.0 / sqrt(9.0 * d);
+	for(;;) {
+		float x = RandNormFloat(counter, key);
+		float v = 1.0 + c * x;
+		if(v <= 0.0) {
+			continue;
+		}
+		v = v * v * v;
+		float u = RandFloat(counter, key);
+		float x2 = x * x;
+		if(u < 1.0 - 0.0331 * x2 * x2) {
+			return d * v * scale;
+		}
+		if(log(u) < 0.5 * x2 + d * (1.0 - v + log(v))) {
+			return d * v * scale;
+		}
+	}
+	return 0.0;
+}
+
+// RandLogFactorials are log(k!) for k = 0..9
+static const float RandLogFactorials[10] = {0, 0, 0.69314718, 1.79175947, 3.17805383, 4.78749174, 6.57925121, 8.52516136, 10.6046029, 12.8018275};
+
+// RandLogFactorial returns the log of k!, for the non-negative integer
+// value k, using a table for k < 10 and the Stirling series otherwise.
+float RandLogFactorial(float k) {
+	if(k < 10.0) {
+		return RandLogFactorials[int(k)];
+	}
+	const float halfLog2Pi = 0.91893853320467274;
+	float ik = 1.0 / k;
+	return (k + 0.5) * log(k) - k + halfLog2Pi + ik * (1.0 / 12.0 - ik * ik * (1.0 / 360.0));
+}
+
+// RandPoissonUint returns a random uint distributed according to
+// the Poisson distribution with given mean lambda >= 0, e.g., the number
+// of spikes in a time interval with an expected number of lambda spikes.
+// For lambda < 10, Knuth's multiplication method is used, which consumes
+// lambda+1 counter increments on average.  Otherwise the transformed
+// rejection method with squeeze of Hormann (1993) (PTRS) is used,
+// which consumes about 2 counter increments on average.
+uint RandPoissonUint(inout uint2 counter, uint key, float lambda) {
+	if(lambda <= 0.0) {
+		return 0;
+	}
+	if(lambda < 10.0) {
+		float el = exp(-lambda);
+		uint k = 0;
+		float p = RandFloat(counter, key);
+		while(p > el) {
+			k++;
+			p *= RandFloat(counter, key);
+		}
+		return k;
+	}
+	float slam = sqrt(lambda);
+	float loglam = log(lambda);
+	float b = 0.931 + 2.53 * slam;
+	float a = -0.059 + 0.02483 * b;
+	float invalpha = 1.1239 + 1.1328 / (b - 3.4);
+	float vr = 0.9277 - 3.6224 / (b - 2.0);
+	for(;;) {
+		float u = RandFloat(counter, key) - 0.5;
+		float v = RandFloat(counter, key);
+		float us = 0.5 - abs(u);
+		float k = floor((2.0 * a / us + b) * u + lambda + 0.43);
+		if(us >= 0.07 && v <= vr) {
+			return uint(k);
+		}
+		if(k < 0.0 || (us < 0.013 && v > us)) {
+			continue;
+		}
+		if(log(v) + log(invalpha) - log(a / (us * us) + b) <= -lambda + k * loglam - RandLogFactorial(k)) {
+			return uint(k);
+		}
+	}
+	return 0;
+}
+
+// RandBinomialLowP is RandBinomialUint for 0 < p <= 0.5
+uint RandBinomialLowP(inout uint2 counter, uint key, uint n, float p) {
+	float fn = float(n);
+	if(fn * p < 10.0) {
+		float lq = log(1.0 - p);
+		uint k = 0;
+		float sum = 0.0;
+		for(;;) {
+			sum += ceil(log(RandFloat(counter, key)) / lq);
+			if(sum > fn) {
+				return k;
+			}
+			k++;
+		}
+	}
+	float q = 1.0 - p;
+	float spq = sqrt(fn * p * q);
+	float b = 1.15 + 2.53 * spq;
+	float a = -0.0873 + 0.0248 * b + 0.01 * p;
+	float c = fn * p + 0.5;
+	float vr = 0.92 - 4.2 / b;
+	float alpha = (2.83 + 5.1 / b) * spq;
+	float lpq = log(p / q);
+	float m = floor((fn + 1.0) * p);
+	float h = RandLogFactorial(m) + RandLogFactorial(fn - m);
+	for(;;) {
+		float u = RandFloat(counter, key) - 0.5;
+		float v = RandFloat(counter, key);
+		float us = 0.5 - abs(u);
+		float k = floor((2.0 * a / us + b) * u + c);
+		if(k < 0.0 || k > fn) {
+			continue;
+		}
+		if(us >= 0.07 && v <= vr) {
+			return uint(k);
+		}
+		v = log(v * alpha / (a / (us * us) + b));
+		if(v <= h - RandLogFactorial(k) - RandLogFactorial(fn - k) + (k - m) * lpq) {
+			return uint(k);
+		}
+	}
+	return 0;
+}
+
+// RandBinomialUint returns a random uint distributed according to
+// the binomial distribution: the number of successes in n independent
+// trials each with probability p of success.
+// For n * min(p, 1-p) < 10, the geometric waiting time method is used,
+// which consumes n * min(p, 1-p) + 1 counter increments on average.
+// Otherwise the transformed rejection method with squeeze of
+// Hormann (1993) (BTRS) is used, which consumes about 2 counter
+// increments on average.
+uint RandBinomialUint(inout uint2 counter, uint key, uint n, float p) {
+	if(p <= 0.0 || n == 0) {
+		return 0;
+	}
+	if(p >= 1.0) {
+		return n;
+	}
+	if(p > 0.5) {
+		return n - RandBinomialLowP(counter, key, n, 1.0 - p);
+	}
+	return RandBinomialLowP(counter, key, n, p);
+}
+
 // Counter is used for storing the random counter using aligned 16 byte storage,
 // with convenience methods for typical use cases.
 // It retains a copy of the last Seed value, which is applied to the Hi uint32 value.
@@ -311,3 +652,53 @@ struct RandCounter {
 	}
 };
 
+// RandCounter4 is used for storing the 128 bit random counter for the
+// Philox4x32 functions, using aligned 16 byte storage, with
+// convenience methods for typical use cases.
+// It retains a copy of the last Seed value, which is applied to the
+// upper 64 bits of the counter (Hi).
+struct RandCounter4 {
+	uint2 Lo;
+	uint2 Hi;
+	uint2 HiSeed;
+	
+	uint pad;
+	uint pad1;
+	
+	// Reset resets counter to last set Seed state
+	void Reset() {
+		this.Lo = uint2(0, 0);
+		this.Hi = this.HiSeed;
+	}
+	
+	// Uint4 returns counter as a Uint4
+	uint4 Uint4() {
+		return uint4(this.Lo.x, this.Lo.y, this.Hi.x, this.Hi.y);
+	}
+	
+	// Set sets the counter from a Uint4
+	void Set(uint4 c) {
+		this.Lo = c.xy;
+		this.Hi = c.zw;
+	}
+
+	// Seed sets the upper 64 bits of the counter from given seed,
+	// with the lower 32 bits of the seed in x and the upper in y,
+	// saving it in HiSeed field.  Resets Lo to 0.
+	// This same seed will be restored during Reset
+	void Seed(uint2 seed) {
+		this.Lo = uint2(0, 0);
+		this.Hi = seed;
+		this.HiSeed = seed;
+	}
+	
+	// Add increments the counter by given amount.
+	// Call this after thread completion with number of random numbers
+	// generated per thread.
+	uint4 Add(int inc) {
+		uint4 c = this.Uint4();
+		Counter4Add(c, inc);
+		this.Set(c);
+		return c;
+	}
+};
@@ -115,3 +115,269 @@ func TestIntn(t *testing.T) {
 		// fmt.Printf("%d\t%d\n", i, r)
 	}
 }
+
+// meanVar returns the sample mean and variance of n values from fun
+func meanVar(n int, fun func() float64) (mean, vr float64) {
+	sum, sumsq := 0.0, 0.0
+	for i := 0; i < n; i++ {
+		v := fun()
+		sum += v
+		sumsq += v * v
+	}
+	mean = sum / float64(n)
+	vr = sumsq/float64(n) - mean*mean
+	return
+}
+
+// checkMeanVar checks that the sample mean and variance of n values from
+// fun are within 5 standard errors of the mean, and 5% of the variance.
+func checkMeanVar(t *testing.T, name string, n int, mean, vr float64, fun func() float64) {
+	t.Helper()
+	m, v := meanVar(n, fun)
+	se := math.Sqrt(vr / float64(n))
+	if math.Abs(m-mean) > 5*se {
+		t.Errorf("%s: mean: %g != expected: %g (se: %g)", name, m, mean, se)
+	}
+	if math.Abs(v-vr) > 0.05*vr {
+		t.Errorf("%s: variance: %g != expected: %g", name, v, vr)
+	}
+}
+
+func TestExpFloat(t *testing.T) {
+	var counter sltype.Uint2
+	checkMeanVar(t, "ExpFloat", 100000, 1, 1, func() float64 {
+		f := ExpFloat(&counter, 1)
+		if f <= 0 {
+			t.Errorf("ExpFloat <= 0: %g", f)
+		}
+		return float64(f)
+	})
+}
+
+func TestGammaFloat(t *testing.T) {
+	for _, shape := range []float32{0.5, 1, 2.5, 10} {
+		var counter sltype.Uint2
+		scale := float32(2)
+		mean := float64(shape * scale)
+		checkMeanVar(t, fmt.Sprintf("GammaFloat shape: %g", shape), 100000, mean, mean*float64(scale), func() float64 {
+			return float64(GammaFloat(&counter, 2, shape, scale))
+		})
+	}
+}
+
+func TestPoissonUint(t *testing.T) {
+	for _, lambda := range []float32{0.1, 2, 9.5, 10, 30, 1000} {
+		var counter sltype.Uint2
+		n0 := 0
+		n := 100000
+		checkMeanVar(t, fmt.Sprintf("PoissonUint lambda: %g", lambda), n, float64(lambda), float64(lambda), func() float64 {
+			k := PoissonUint(&counter, 3, lambda)
+			if k == 0 {
+				n0++
+			}
+			return float64(k)
+		})
+		if lambda < 10 { // check P(0) = exp(-lambda)
+			p0 := math.Exp(-float64(lambda))
+			se := math.Sqrt(p0 * (1 - p0) / float64(n))
+			if math.Abs(float64(n0)/float64(n)-p0) > 5*se {
+				t.Errorf("PoissonUint lambda: %g P(0): %g != expected: %g", lambda, float64(n0)/float64(n), p0)
+			}
+		}
+	}
+	var counter sltype.Uint2
+	if k := PoissonUint(&counter, 0, 0); k != 0 {
+		t.Errorf("PoissonUint lambda 0 = %d", k)
+	}
+}
+
+func TestBinomialUint(t *testing.T) {
+	for _, tv := range []struct {
+		n uint32
+		p float32
+	}{{10, 0.1}, {20, 0.5}, {100, 0.05}, {100, 0.3}, {100, 0.9}, {5000, 0.02}, {5000, 0.5}} {
+		var counter sltype.Uint2
+		mean := float64(tv.n) * float64(tv.p)
+		checkMeanVar(t, fmt.Sprintf("BinomialUint n: %d p: %g", tv.n, tv.p), 100000, mean, mean*(1-float64(tv.p)), func() float64 {
+			k := BinomialUint(&counter, 4, tv.n, tv.p)
+			if k > tv.n {
+				t.Errorf("BinomialUint: %d > n: %d", k, tv.n)
+			}
+			return float64(k)
+		})
+	}
+	var counter sltype.Uint2
+	if k := BinomialUint(&counter, 0, 10, 0); k != 0 {
+		t.Errorf("BinomialUint p 0 = %d", k)
+	}
+	if k := BinomialUint(&counter, 0, 10, 1); k != 10 {
+		t.Errorf("BinomialUint p 1 = %d", k)
+	}
+}
+
+func TestLogFactorial(t *testing.T) {
+	for k := 0; k < 100; k++ {
+		lg, _ := math.Lgamma(float64(k) + 1)
+		if lf := LogFactorial(float32(k)); math.Abs(float64(lf)-lg) > 1e-5*math.Max(1, lg) {
+			t.Errorf("LogFactorial(%d) = %g != %g", k, lf, lg)
+		}
+	}
+}
+
+// Known Answer Test for Philox4x32 values from the DEShawREsearch reference impl
+func TestKAT4(t *testing.T) {
+	kats := []struct {
+		ctr sltype.Uint4
+		key sltype.Uint2
+		res sltype.Uint4
+	}{{sltype.Uint4{X: 0, Y: 0, Z: 0, W: 0}, sltype.Uint2{X: 0, Y: 0}, sltype.Uint4{X: 0x6627e8d5, Y: 0xe169c58d, Z: 0xbc57ac4c, W: 0x9b00dbd8}},
+		{sltype.Uint4{X: 0xffffffff, Y: 0xffffffff, Z: 0xffffffff, W: 0xffffffff}, sltype.Uint2{X: 0xffffffff, Y: 0xffffffff}, sltype.Uint4{X: 0x408f276d, Y: 0x41c83b0e, Z: 0xa20bc7c6, W: 0x6d5451fd}},
+		{sltype.Uint4{X: 0x243f6a88, Y: 0x85a308d3, Z: 0x13198a2e, W: 0x03707344}, sltype.Uint2{X: 0xa4093822, Y: 0x299f31d0}, sltype.Uint4{X: 0xd16cfe09, Y: 0x94fdcceb, Z: 0x5001e420, W: 0x24126ea1}}}
+
+	for _, tv := range kats {
+		r := Philox4x32(tv.ctr, tv.key)
+		if r != tv.res {
+			t.Errorf("ctr: %x  key: %x != result: %x -- got: %x", tv.ctr, tv.key, tv.res, r)
+		}
+	}
+}
+
+func TestCounter4(t *testing.T) {
+	ctr := sltype.Uint4{X: 0xfffffffe, Y: 0xffffffff, Z: 0xffffffff, W: 0}
+	Counter4Add(&ctr, 4)
+	if (ctr != sltype.Uint4{X: 2, W: 1}) {
+		t.Errorf("Should be 2, 0, 0, 1: %v", ctr)
+	}
+	ctr = sltype.Uint4{X: 0xfffffffe, Y: 7}
+	Counter4Incr(&ctr)
+	if (ctr != sltype.Uint4{X: 0xffffffff, Y: 7}) {
+		t.Errorf("Should be 0xffffffff, 7, 0, 0: %v", ctr)
+	}
+	Counter4Incr(&ctr)
+	if (ctr != sltype.Uint4{X: 0, Y: 8}) {
+		t.Errorf("Should be 0, 8, 0, 0: %v", ctr)
+	}
+
+	var ct Counter4
+	ct.Seed(0x123456789)
+	if (ct.Uint4() != sltype.Uint4{Z: 0x23456789, W: 1}) {
+		t.Errorf("Seed: %v", ct.Uint4())
+	}
+	ct.Add(0xffffffff)
+	ct.Add(2)
+	if (ct.Uint4() != sltype.Uint4{X: 1, Y: 1, Z: 0x23456789, W: 1}) {
+		t.Errorf("Add: %v", ct.Uint4())
+	}
+	ct.Reset()
+	if (ct.Uint4() != sltype.Uint4{Z: 0x23456789, W: 1}) {
+		t.Errorf("Reset: %v", ct.Uint4())
+	}
+}
+
+// TestPhilox4x32 verifies that Philox4x32 computed with the 32 bit
+// multiply used in the HLSL code produces the same stream.
+func TestPhilox4x32(t *testing.T) {
+	philox32 := func(counter sltype.Uint4, key sltype.Uint2) sltype.Uint4 {
+		for i := 0; i < 10; i++ {
+			if i > 0 {
+				Philox4x32bumpkey(&key)
+			}
+			lo0, hi0 := mulHiLo32(0xD2511F53, counter.X)
+			lo1, hi1 := mulHiLo32(0xCD9E8D57, counter.Z)
+			counter = sltype.Uint4{X: hi1 ^ counter.Y ^ key.X, Y: lo1, Z: hi0 ^ counter.W ^ key.Y, W: lo0}
+		}
+		return counter
+	}
+	var counter sltype.Uint4
+	for i := 0; i < 10000; i++ {
+		key := sltype.Uint2{X: uint32(i) * 0x9E3779B9, Y: uint32(i)}
+		if r, e := philox32(counter, key), Philox4x32(counter, key); r != e {
+			t.Fatalf("philox32(%v, %v) = %v != %v", counter, key, r, e)
+		}
+		Counter4Add(&counter, 0x10001)
+	}
+}
+
+func TestFloat4(t *testing.T) {
+	var counter sltype.Uint4
+	key := sltype.Uint2{X: 1, Y: 2}
+	for i := 0; i < 1000; i++ {
+		f := Float4(&counter, key)
+		for _, v := range []float32{f.X, f.Y, f.Z, f.W} {
+			if v <= 0 || v >= 1 {
+				t.Errorf("Float4 out of (0,1): %g", v)
+			}
+		}
+		f = Float114(&counter, key)
+		for _, v := range []float32{f.X, f.Y, f.Z, f.W} {
+			if v < -1 || v > 1 || v == 0 {
+				t.Errorf("Float114 out of [-1,1]: %g", v)
+			}
+		}
+	}
+	if (counter != sltype.Uint4{X: 2000}) {
+		t.Errorf("counter should be 2000: %v", counter)
+	}
+	i := 0
+	var f sltype.Float4
+	checkMeanVar(t, "Float4", 100000, 0.5, 1.0/12, func() float64 {
+		if i%4 == 0 {
+			f = Float4(&counter, key)
+		}
+		v := []float32{f.X, f.Y, f.Z, f.W}[i%4]
+		i++
+		return float64(v)
+	})
+	checkMeanVar(t, "NormFloat4", 100000, 0, 1, func() float64 {
+		if i%4 == 0 {
+			f = NormFloat4(&counter, key)
+		}
+		v := []float32{f.X, f.Y, f.Z, f.W}[i%4]
+		i++
+		return float64(v)
+	})
+}
+
+// TestStreams verifies that all the numbers generated for a realistic
+// number of (seed, stream, item, step) combinations are unique.
+func TestStreams(t *testing.T) {
+	type input struct {
+		ctr sltype.Uint4
+		key sltype.Uint2
+	}
+	inputs := map[input]bool{}
+	outputs := map[sltype.Uint4]bool{}
+	for seed := uint32(0); seed < 2; seed++ {
+		for stream := uint32(0); stream < 4; stream++ {
+			for item := uint32(0); item < 1000; item++ {
+				key := StreamKey(seed, item)
+				for step := uint32(0); step < 10; step++ {
+					ctr := StreamCounter(stream, step)
+					for i := 0; i < 4; i++ {
+						in := input{ctr, key}
+						if inputs[in] {
+							t.Fatalf("seed: %d stream: %d item: %d step: %d draw: %d: duplicate input: %v", seed, stream, item, step, i, in)
+						}
+						inputs[in] = true
+						r := Uint4(&ctr, key)
+						if outputs[r] {
+							t.Fatalf("seed: %d stream: %d item: %d step: %d draw: %d: duplicate output: %v", seed, stream, item, step, i, r)
+						}
+						outputs[r] = true
+					}
+				}
+			}
+		}
+	}
+
+	// 2^32 draws within a step do not reach the next step
+	ctr := StreamCounter(1, 5)
+	Counter4Add(&ctr, 0xffffffff)
+	if next := StreamCounter(1, 6); ctr == next {
+		t.Errorf("step overlaps with next step: %v", ctr)
+	}
+	Counter4Incr(&ctr)
+	if next := StreamCounter(1, 6); ctr != next {
+		t.Errorf("step should reach next step after 2^32 draws: %v != %v", ctr, next)
+	}
+}
@@ -0,0 +1,324 @@
+// Copyright (c) 2024, Cogent Core. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package slrand
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/tomas-mraz/vgpu/gosl/sltype"
+)
+
+// Statistical quality tests of the Philox2x32 streams, and the last
+// output word of Philox4x32, run on the CPU.
+// The HLSL code computes the same streams using only 32 bit uints,
+// which is verified by TestMulHiLo32 and TestPhilox32.
+// The default sizes run quickly -- use go test -long for much larger
+// sample sizes and numbers of keys.
+
+var long = flag.Bool("long", false, "run long versions of the statistical tests")
+
+// pCrit is the p-value below which a test statistic is rejected.
+// All tests are deterministic, so this only needs to be small enough
+// that a good generator does not fail any of the tests by chance.
+const pCrit = 1e-5
+
+// statSizes returns the number of samples and keys for the tests
+func statSizes() (n int, nkeys []uint32) {
+	if *long {
+		return 10000000, []uint32{1, 1024, 100000}
+	}
+	return 100000, []uint32{1, 1024}
+}
+
+// statGen is a generator function to test, with the cumulative
+// distribution function (CDF) of the values it produces.
+type statGen struct {
+	name string
+	fun  func(counter *sltype.Uint2, key uint32) float64
+	cdf  func(x float64) float64
+}
+
+var statGens = []statGen{
+	{"Uint32", func(counter *sltype.Uint2, key uint32) float64 {
+		return (float64(Uint32(counter, key)) + 0.5) / (1 << 32)
+	}, func(x float64) float64 { return x }},
+	{"Float", func(counter *sltype.Uint2, key uint32) float64 {
+		return float64(Float(counter, key))
+	}, func(x float64) float64 { return x }},
+	{"Float11", func(counter *sltype.Uint2, key uint32) float64 {
+		return float64(Float11(counter, key))
+	}, func(x float64) float64 { return (x + 1) / 2 }},
+	{"NormFloat", func(counter *sltype.Uint2, key uint32) float64 {
+		return float64(NormFloat(counter, key))
+	}, func(x float64) float64 { return 0.5 * math.Erfc(-x/math.Sqrt2) }},
+	{"Philox4x32", func(counter *sltype.Uint2, key uint32) float64 {
+		ctr := sltype.Uint4{X: counter.X, Y: counter.Y}
+		CounterIncr(counter)
+		return (float64(Philox4x32(ctr, sltype.Uint2{X: key}).W) + 0.5) / (1 << 32)
+	}, func(x float64) float64 { return x }},
+}
+
+// keyStream returns n values from given generator, cycling over nkeys
+// keys at each counter value, as when each GPU thread uses its own key
+// starting from the same counter.  The keys are spread out over the
+// uint32 range.
+func keyStream(gen statGen, n int, nkeys uint32) []float64 {
+	ctrs := make([]sltype.Uint2, nkeys)
+	stride := uint32(0xffffffff) / nkeys
+	vals := make([]float64, n)
+	for i := range vals {
+		ki := uint32(i) % nkeys
+		vals[i] = gen.fun(&ctrs[ki], ki*stride)
+	}
+	return vals
+}
+
+// chiSqP returns the upper tail p-value of a chi-squared statistic
+// with df degrees of freedom, using the Wilson-Hilferty approximation.
+func chiSqP(chi2 float64, df int) float64 {
+	k := float64(df)
+	z := (math.Cbrt(chi2/k) - (1 - 2/(9*k))) / math.Sqrt(2/(9*k))
+	return 0.5 * math.Erfc(z/math.Sqrt2)
+}
+
+// ksP returns the p-value of the Kolmogorov-Smirnov statistic d
+// for n samples.
+func ksP(d float64, n int) float64 {
+	sn := math.Sqrt(float64(n))
+	lam := (sn + 0.12 + 0.11/sn) * d
+	p := 0.0
+	sign := 1.0
+	for k := 1; k <= 100; k++ {
+		p += sign * 2 * math.Exp(-2*float64(k*k)*lam*lam)
+		sign = -sign
+	}
+	return min(max(p, 0), 1)
+}
+
+// checkP reports an error if p-value p is below pCrit
+func checkP(t *testing.T, name string, stat, p float64) {
+	t.Helper()
+	if p < pCrit {
+		t.Errorf("%s: statistic: %g p: %g < %g", name, stat, p, pCrit)
+	}
+}
+
+// forStreams runs fun for each generator and number of keys
+func forStreams(t *testing.T, fun func(t *testing.T, gen statGen, vals []float64)) {
+	n, nkeys := statSizes()
+	for _, gen := range statGens {
+		for _, nk := range nkeys {
+			t.Run(fmt.Sprintf("%s/keys%d", gen.name, nk), func(t *testing.T) {
+				fun(t, gen, keyStream(gen, n, nk))
+			})
+		}
+	}
+}
+
+// TestChiSquared tests uniformity of the CDF-transformed values
+// in equal-sized bins.
+func TestChiSquared(t *testing.T) {
+	forStreams(t, func(t *testing.T, gen statGen, vals []float64) {
+		nbins := 1000
+		bins := make([]int, nbins)
+		for _, v := range vals {
+			bi := min(int(gen.cdf(v)*float64(nbins)), nbins-1)
+			bins[bi]++
+		}
+		exp := float64(len(vals)) / float64(nbins)
+		chi2 := 0.0
+		for _, b := range bins {
+			d := float64(b) - exp
+			chi2 += d * d / exp
+		}
+		checkP(t, "chi-squared", chi2, chiSqP(chi2, nbins-1))
+	})
+}
+
+// TestKS tests the distribution with the Kolmogorov-Smirnov test.
+func TestKS(t *testing.T) {
+	forStreams(t, func(t *testing.T, gen statGen, vals []float64) {
+		slices.Sort(vals)
+		n := float64(len(vals))
+		d := 0.0
+		for i, v := range vals {
+			f := gen.cdf(v)
+			d = max(d, f-float64(i)/n, float64(i+1)/n-f)
+		}
+		checkP(t, "Kolmogorov-Smirnov", d, ksP(d, len(vals)))
+	})
+}
+
+// TestSerialCorrelation tests the correlation between successive values
+// in the stream, for lags 1 to 4, which is normally distributed with
+// variance 1/n for independent values.
+func TestSerialCorrelation(t *testing.T) {
+	forStreams(t, func(t *testing.T, gen statGen, vals []float64) {
+		us := make([]float64, len(vals))
+		mean := 0.0
+		for i, v := range vals {
+			us[i] = gen.cdf(v)
+			mean += us[i]
+		}
+		mean /= float64(len(us))
+		vr := 0.0
+		for _, u := range us {
+			vr += (u - mean) * (u - mean)
+		}
+		for lag := 1; lag <= 4; lag++ {
+			cv := 0.0
+			for i := lag; i < len(us); i++ {
+				cv += (us[i] - mean) * (us[i-lag] - mean)
+			}
+			r := cv / vr
+			z := r * math.Sqrt(float64(len(us)-lag))
+			checkP(t, fmt.Sprintf("serial correlation lag %d", lag), r, math.Erfc(math.Abs(z)/math.Sqrt2))
+		}
+	})
+}
+
+// poissonPMF returns the Poisson probability of k for given lambda
+func poissonPMF(k int, lambda float64) float64 {
+	lg, _ := math.Lgamma(float64(k) + 1)
+	return math.Exp(float64(k)*math.Log(lambda) - lambda - lg)
+}
+
+// TestBirthdaySpacings is Marsaglia's birthday spacings test, with the
+// Diehard parameters: m = 512 birthdays (the top 24 bits of Uint32 values)
+// in a year of 2^24 days, where the number of duplicate spacings between
+// sorted birthdays is approximately Poisson distributed with
+// lambda = m^3 / (4 * 2^24) = 2.  The counts over many trials are tested
+// with a chi-squared test.  (Larger m gives larger lambda, but the Poisson
+// approximation is then too inaccurate for the long run trials.)
+func TestBirthdaySpacings(t *testing.T) {
+	_, nkeys := statSizes()
+	trials := 500
+	if *long {
+		trials = 20000
+	}
+	const m = 512
+	const lambda = float64(m*m*m) / (4 * (1 << 24))
+	lo, hi := 0, 6 // bins: <= lo, each k in between, >= hi
+	for _, nk := range nkeys {
+		t.Run(fmt.Sprintf("keys%d", nk), func(t *testing.T) {
+			vals := keyStream(statGens[0], trials*m, nk)
+			counts := make([]int, hi-lo+1)
+			bdays := make([]uint32, m)
+			spaces := make([]uint32, m)
+			for tr := 0; tr < trials; tr++ {
+				for i := range bdays {
+					bdays[i] = uint32(vals[tr*m+i]*(1<<32)) >> 8
+				}
+				slices.Sort(bdays)
+				spaces[0] = bdays[0]
+				for i := 1; i < m; i++ {
+					spaces[i] = bdays[i] - bdays[i-1]
+				}
+				slices.Sort(spaces)
+				dups := 0
+				for i := 1; i < m; i++ {
+					if spaces[i] == spaces[i-1] {
+						dups++
+					}
+				}
+				counts[min(max(dups, lo), hi)-lo]++
+			}
+			probs := make([]float64, len(counts))
+			for k := 0; k <= lo; k++ {
+				probs[0] += poissonPMF(k, lambda)
+			}
+			tot := probs[0]
+			for k := lo + 1; k < hi; k++ {
+				probs[k-lo] = poissonPMF(k, lambda)
+				tot += probs[k-lo]
+			}
+			probs[hi-lo] = 1 - tot
+			chi2 := 0.0
+			for bi, c := range counts {
+				exp := probs[bi] * float64(trials)
+				d := float64(c) - exp
+				chi2 += d * d / exp
+			}
+			checkP(t, "birthday spacings", chi2, chiSqP(chi2, len(counts)-1))
+		})
+	}
+}
+
+// mulHiLo32 is the Go version of the HLSL MulHiLo32 function,
+// which computes the hi-lo multiply using only 32 bit uints.
+func mulHiLo32(a, b uint32) (lo, hi uint32) {
+	const LOMASK = (uint32(1) << 16) - 1
+	lo = a * b
+	ahi := a >> 16
+	alo := a & LOMASK
+	bhi := b >> 16
+	blo := b & LOMASK
+
+	ahbl := ahi * blo
+	albh := alo * bhi
+
+	ahbl_albh := ((ahbl & LOMASK) + (albh & LOMASK))
+	hit := ahi*bhi + (ahbl >> 16) + (albh >> 16)
+	hit += ahbl_albh >> 16
+	if (lo >> 16) < (ahbl_albh & LOMASK) {
+		hit++
+	}
+	hi = hit
+	return
+}
+
+// TestMulHiLo32 verifies that the 32 bit multiply used in the HLSL
+// code is identical to the 64 bit one used in Go.
+func TestMulHiLo32(t *testing.T) {
+	n, _ := statSizes()
+	var counter sltype.Uint2
+	check := func(a, b uint32) {
+		lo, hi := mulHiLo32(a, b)
+		elo, ehi := MulHiLo64(a, b)
+		if lo != elo || hi != ehi {
+			t.Errorf("mulHiLo32(%x, %x) = %x, %x != %x, %x", a, b, lo, hi, elo, ehi)
+		}
+	}
+	edges := []uint32{0, 1, 0xffff, 0x10000, 0x7fffffff, 0x80000000, 0xfffffffe, 0xffffffff, 0xD256D193}
+	for _, a := range edges {
+		for _, b := range edges {
+			check(a, b)
+		}
+	}
+	for i := 0; i < n; i++ {
+		r := Uint2(&counter, 0)
+		check(r.X, r.Y)
+		check(0xD256D193, r.X)
+	}
+}
+
+// TestPhilox32 verifies that Philox2x32 computed with the 32 bit
+// multiply used in the HLSL code produces the same stream.
+func TestPhilox32(t *testing.T) {
+	n, _ := statSizes()
+	philox32 := func(counter sltype.Uint2, key uint32) sltype.Uint2 {
+		for i := 0; i < 10; i++ {
+			if i > 0 {
+				Philox2x32bumpkey(&key)
+			}
+			lo, hi := mulHiLo32(0xD256D193, counter.X)
+			counter.X = hi ^ key ^ counter.Y
+			counter.Y = lo
+		}
+		return counter
+	}
+	var counter sltype.Uint2
+	for i := 0; i < n/10; i++ {
+		key := uint32(i) * 0x9E3779B9
+		if r, e := philox32(counter, key), Philox2x32(counter, key); r != e {
+			t.Fatalf("philox32(%v, %x) = %v != %v", counter, key, r, e)
+		}
+		CounterAdd(&counter, 0x10001)
+	}
+}
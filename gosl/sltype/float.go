@@ -9,10 +9,13 @@ import "cogentcore.org/core/math32"
 // Float is identical to a float32
 type Float = float32
 
-// Float2 is a length 2 vector of float32
+// Float2 is a length 2 vector of float32.
+// The math32 methods Add, Sub, Mul, Div (and Scalar versions),
+// Dot, Length, Normal, Min, Max are translated into HLSL by gosl,
+// as for all the vector types.
 type Float2 = math32.Vector2
 
-// Float3 is a length 3 vector of float32
+// Float3 is a length 3 vector of float32, which also has Cross
 type Float3 = math32.Vector3
 
 // Float4 is a length 4 vector of float32
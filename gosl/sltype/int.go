@@ -4,16 +4,293 @@
 
 package sltype
 
-import "cogentcore.org/core/math32"
-
 // Int is identical to an int32
 type Int = int32
 
 // Int2 is a length 2 vector of int32
-type Int2 = math32.Vector2i
+type Int2 struct {
+	X int32
+	Y int32
+}
+
+// Set sets the vector components
+func (v *Int2) Set(x, y int32) {
+	v.X = x
+	v.Y = y
+}
+
+// Add returns the component-wise sum of this vector and other
+func (v Int2) Add(other Int2) Int2 {
+	return Int2{v.X + other.X, v.Y + other.Y}
+}
+
+// AddScalar returns the sum of each component of this vector and s
+func (v Int2) AddScalar(s int32) Int2 {
+	return Int2{v.X + s, v.Y + s}
+}
+
+// Sub returns the component-wise difference of this vector and other
+func (v Int2) Sub(other Int2) Int2 {
+	return Int2{v.X - other.X, v.Y - other.Y}
+}
+
+// SubScalar returns the difference of each component of this vector and s
+func (v Int2) SubScalar(s int32) Int2 {
+	return Int2{v.X - s, v.Y - s}
+}
+
+// Mul returns the component-wise product of this vector and other
+func (v Int2) Mul(other Int2) Int2 {
+	return Int2{v.X * other.X, v.Y * other.Y}
+}
+
+// MulScalar returns the product of each component of this vector and s
+func (v Int2) MulScalar(s int32) Int2 {
+	return Int2{v.X * s, v.Y * s}
+}
+
+// Div returns the component-wise quotient of this vector and other
+func (v Int2) Div(other Int2) Int2 {
+	return Int2{v.X / other.X, v.Y / other.Y}
+}
+
+// DivScalar returns the quotient of each component of this vector and s
+func (v Int2) DivScalar(s int32) Int2 {
+	return Int2{v.X / s, v.Y / s}
+}
+
+// SetAdd sets this vector to the component-wise sum of it and other
+func (v *Int2) SetAdd(other Int2) {
+	v.X += other.X
+	v.Y += other.Y
+}
+
+// SetAddScalar sets each component of this vector to the sum of it and s
+func (v *Int2) SetAddScalar(s int32) {
+	v.X += s
+	v.Y += s
+}
+
+// SetSub sets this vector to the component-wise difference of it and other
+func (v *Int2) SetSub(other Int2) {
+	v.X -= other.X
+	v.Y -= other.Y
+}
+
+// SetSubScalar sets each component of this vector to the difference of it and s
+func (v *Int2) SetSubScalar(s int32) {
+	v.X -= s
+	v.Y -= s
+}
+
+// SetMul sets this vector to the component-wise product of it and other
+func (v *Int2) SetMul(other Int2) {
+	v.X *= other.X
+	v.Y *= other.Y
+}
+
+// SetMulScalar sets each component of this vector to the product of it and s
+func (v *Int2) SetMulScalar(s int32) {
+	v.X *= s
+	v.Y *= s
+}
+
+// SetDiv sets this vector to the component-wise quotient of it and other
+func (v *Int2) SetDiv(other Int2) {
+	v.X /= other.X
+	v.Y /= other.Y
+}
+
+// SetDivScalar sets each component of this vector to the quotient of it and s
+func (v *Int2) SetDivScalar(s int32) {
+	v.X /= s
+	v.Y /= s
+}
+
+// Min returns the component-wise minimum of this vector and other
+func (v Int2) Min(other Int2) Int2 {
+	return Int2{min(v.X, other.X), min(v.Y, other.Y)}
+}
+
+// Max returns the component-wise maximum of this vector and other
+func (v Int2) Max(other Int2) Int2 {
+	return Int2{max(v.X, other.X), max(v.Y, other.Y)}
+}
+
+// SetMin sets this vector to the component-wise minimum of it and other
+func (v *Int2) SetMin(other Int2) {
+	*v = v.Min(other)
+}
+
+// SetMax sets this vector to the component-wise maximum of it and other
+func (v *Int2) SetMax(other Int2) {
+	*v = v.Max(other)
+}
+
+// SetZero sets all of the vector components to zero
+func (v *Int2) SetZero() {
+	*v = Int2{}
+}
+
+// Negate returns the vector with each component negated
+func (v Int2) Negate() Int2 {
+	return Int2{-v.X, -v.Y}
+}
+
+// Dot returns the dot product of this vector and other
+func (v Int2) Dot(other Int2) int32 {
+	return v.X*other.X + v.Y*other.Y
+}
 
 // Int3 is a length 3 vector of int32
-type Int3 = math32.Vector3i
+type Int3 struct {
+	X int32
+	Y int32
+	Z int32
+}
+
+// Set sets the vector components
+func (v *Int3) Set(x, y, z int32) {
+	v.X = x
+	v.Y = y
+	v.Z = z
+}
+
+// Add returns the component-wise sum of this vector and other
+func (v Int3) Add(other Int3) Int3 {
+	return Int3{v.X + other.X, v.Y + other.Y, v.Z + other.Z}
+}
+
+// AddScalar returns the sum of each component of this vector and s
+func (v Int3) AddScalar(s int32) Int3 {
+	return Int3{v.X + s, v.Y + s, v.Z + s}
+}
+
+// Sub returns the component-wise difference of this vector and other
+func (v Int3) Sub(other Int3) Int3 {
+	return Int3{v.X - other.X, v.Y - other.Y, v.Z - other.Z}
+}
+
+// SubScalar returns the difference of each component of this vector and s
+func (v Int3) SubScalar(s int32) Int3 {
+	return Int3{v.X - s, v.Y - s, v.Z - s}
+}
+
+// Mul returns the component-wise product of this vector and other
+func (v Int3) Mul(other Int3) Int3 {
+	return Int3{v.X * other.X, v.Y * other.Y, v.Z * other.Z}
+}
+
+// MulScalar returns the product of each component of this vector and s
+func (v Int3) MulScalar(s int32) Int3 {
+	return Int3{v.X * s, v.Y * s, v.Z * s}
+}
+
+// Div returns the component-wise quotient of this vector and other
+func (v Int3) Div(other Int3) Int3 {
+	return Int3{v.X / other.X, v.Y / other.Y, v.Z / other.Z}
+}
+
+// DivScalar returns the quotient of each component of this vector and s
+func (v Int3) DivScalar(s int32) Int3 {
+	return Int3{v.X / s, v.Y / s, v.Z / s}
+}
+
+// SetAdd sets this vector to the component-wise sum of it and other
+func (v *Int3) SetAdd(other Int3) {
+	v.X += other.X
+	v.Y += other.Y
+	v.Z += other.Z
+}
+
+// SetAddScalar sets each component of this vector to the sum of it and s
+func (v *Int3) SetAddScalar(s int32) {
+	v.X += s
+	v.Y += s
+	v.Z += s
+}
+
+// SetSub sets this vector to the component-wise difference of it and other
+func (v *Int3) SetSub(other Int3) {
+	v.X -= other.X
+	v.Y -= other.Y
+	v.Z -= other.Z
+}
+
+// SetSubScalar sets each component of this vector to the difference of it and s
+func (v *Int3) SetSubScalar(s int32) {
+	v.X -= s
+	v.Y -= s
+	v.Z -= s
+}
+
+// SetMul sets this vector to the component-wise product of it and other
+func (v *Int3) SetMul(other Int3) {
+	v.X *= other.X
+	v.Y *= other.Y
+	v.Z *= other.Z
+}
+
+// SetMulScalar sets each component of this vector to the product of it and s
+func (v *Int3) SetMulScalar(s int32) {
+	v.X *= s
+	v.Y *= s
+	v.Z *= s
+}
+
+// SetDiv sets this vector to the component-wise quotient of it and other
+func (v *Int3) SetDiv(other Int3) {
+	v.X /= other.X
+	v.Y /= other.Y
+	v.Z /= other.Z
+}
+
+// SetDivScalar sets each component of this vector to the quotient of it and s
+func (v *Int3) SetDivScalar(s int32) {
+	v.X /= s
+	v.Y /= s
+	v.Z /= s
+}
+
+// Min returns the component-wise minimum of this vector and other
+func (v Int3) Min(other Int3) Int3 {
+	return Int3{min(v.X, other.X), min(v.Y, other.Y), min(v.Z, other.Z)}
+}
+
+// Max returns the component-wise maximum of this vector and other
+func (v Int3) Max(other Int3) Int3 {
+	return Int3{max(v.X, other.X), max(v.Y, other.Y), max(v.Z, other.Z)}
+}
+
+// SetMin sets this vector to the component-wise minimum of it and other
+func (v *Int3) SetMin(other Int3) {
+	*v = v.Min(other)
+}
+
+// SetMax sets this vector to the component-wise maximum of it and other
+func (v *Int3) SetMax(other Int3) {
+	*v = v.Max(other)
+}
+
+// SetZero sets all of the vector components to zero
+func (v *Int3) SetZero() {
+	*v = Int3{}
+}
+
+// Negate returns the vector with each component negated
+func (v Int3) Negate() Int3 {
+	return Int3{-v.X, -v.Y, -v.Z}
+}
+
+// Dot returns the dot product of this vector and other
+func (v Int3) Dot(other Int3) int32 {
+	return v.X*other.X + v.Y*other.Y + v.Z*other.Z
+}
+
+// Cross returns the cross product of this vector and other
+func (v Int3) Cross(other Int3) Int3 {
+	return Int3{v.Y*other.Z - v.Z*other.Y, v.Z*other.X - v.X*other.Z, v.X*other.Y - v.Y*other.X}
+}
 
 // Int4 is a length 4 vector of int32
 type Int4 struct {
@@ -23,6 +300,153 @@ type Int4 struct {
 	W int32
 }
 
+// Set sets the vector components
+func (v *Int4) Set(x, y, z, w int32) {
+	v.X = x
+	v.Y = y
+	v.Z = z
+	v.W = w
+}
+
+// Add returns the component-wise sum of this vector and other
+func (v Int4) Add(other Int4) Int4 {
+	return Int4{v.X + other.X, v.Y + other.Y, v.Z + other.Z, v.W + other.W}
+}
+
+// AddScalar returns the sum of each component of this vector and s
+func (v Int4) AddScalar(s int32) Int4 {
+	return Int4{v.X + s, v.Y + s, v.Z + s, v.W + s}
+}
+
+// Sub returns the component-wise difference of this vector and other
+func (v Int4) Sub(other Int4) Int4 {
+	return Int4{v.X - other.X, v.Y - other.Y, v.Z - other.Z, v.W - other.W}
+}
+
+// SubScalar returns the difference of each component of this vector and s
+func (v Int4) SubScalar(s int32) Int4 {
+	return Int4{v.X - s, v.Y - s, v.Z - s, v.W - s}
+}
+
+// Mul returns the component-wise product of this vector and other
+func (v Int4) Mul(other Int4) Int4 {
+	return Int4{v.X * other.X, v.Y * other.Y, v.Z * other.Z, v.W * other.W}
+}
+
+// MulScalar returns the product of each component of this vector and s
+func (v Int4) MulScalar(s int32) Int4 {
+	return Int4{v.X * s, v.Y * s, v.Z * s, v.W * s}
+}
+
+// Div returns the component-wise quotient of this vector and other
+func (v Int4) Div(other Int4) Int4 {
+	return Int4{v.X / other.X, v.Y / other.Y, v.Z / other.Z, v.W / other.W}
+}
+
+// DivScalar returns the quotient of each component of this vector and s
+func (v Int4) DivScalar(s int32) Int4 {
+	return Int4{v.X / s, v.Y / s, v.Z / s, v.W / s}
+}
+
+// SetAdd sets this vector to the component-wise sum of it and other
+func (v *Int4) SetAdd(other Int4) {
+	v.X += other.X
+	v.Y += other.Y
+	v.Z += other.Z
+	v.W += other.W
+}
+
+// SetAddScalar sets each component of this vector to the sum of it and s
+func (v *Int4) SetAddScalar(s int32) {
+	v.X += s
+	v.Y += s
+	v.Z += s
+	v.W += s
+}
+
+// SetSub sets this vector to the component-wise difference of it and other
+func (v *Int4) SetSub(other Int4) {
+	v.X -= other.X
+	v.Y -= other.Y
+	v.Z -= other.Z
+	v.W -= other.W
+}
+
+// SetSubScalar sets each component of this vector to the difference of it and s
+func (v *Int4) SetSubScalar(s int32) {
+	v.X -= s
+	v.Y -= s
+	v.Z -= s
+	v.W -= s
+}
+
+// SetMul sets this vector to the component-wise product of it and other
+func (v *Int4) SetMul(other Int4) {
+	v.X *= other.X
+	v.Y *= other.Y
+	v.Z *= other.Z
+	v.W *= other.W
+}
+
+// SetMulScalar sets each component of this vector to the product of it and s
+func (v *Int4) SetMulScalar(s int32) {
+	v.X *= s
+	v.Y *= s
+	v.Z *= s
+	v.W *= s
+}
+
+// SetDiv sets this vector to the component-wise quotient of it and other
+func (v *Int4) SetDiv(other Int4) {
+	v.X /= other.X
+	v.Y /= other.Y
+	v.Z /= other.Z
+	v.W /= other.W
+}
+
+// SetDivScalar sets each component of this vector to the quotient of it and s
+func (v *Int4) SetDivScalar(s int32) {
+	v.X /= s
+	v.Y /= s
+	v.Z /= s
+	v.W /= s
+}
+
+// Min returns the component-wise minimum of this vector and other
+func (v Int4) Min(other Int4) Int4 {
+	return Int4{min(v.X, other.X), min(v.Y, other.Y), min(v.Z, other.Z), min(v.W, other.W)}
+}
+
+// Max returns the component-wise maximum of this vector and other
+func (v Int4) Max(other Int4) Int4 {
+	return Int4{max(v.X, other.X), max(v.Y, other.Y), max(v.Z, other.Z), max(v.W, other.W)}
+}
+
+// SetMin sets this vector to the component-wise minimum of it and other
+func (v *Int4) SetMin(other Int4) {
+	*v = v.Min(other)
+}
+
+// SetMax sets this vector to the component-wise maximum of it and other
+func (v *Int4) SetMax(other Int4) {
+	*v = v.Max(other)
+}
+
+// SetZero sets all of the vector components to zero
+func (v *Int4) SetZero() {
+	*v = Int4{}
+}
+
+// Negate returns the vector with each component negated
+func (v Int4) Negate() Int4 {
+	return Int4{-v.X, -v.Y, -v.Z, -v.W}
+}
+
+// Dot returns the dot product of this vector and other
+func (v Int4) Dot(other Int4) int32 {
+	return v.X*other.X + v.Y*other.Y + v.Z*other.Z + v.W*other.W
+}
+
 ////////////////////////////////////////
 // Unsigned
 
@@ -35,6 +459,136 @@ type Uint2 struct {
 	Y uint32
 }
 
+// Set sets the vector components
+func (v *Uint2) Set(x, y uint32) {
+	v.X = x
+	v.Y = y
+}
+
+// Add returns the component-wise sum of this vector and other
+func (v Uint2) Add(other Uint2) Uint2 {
+	return Uint2{v.X + other.X, v.Y + other.Y}
+}
+
+// AddScalar returns the sum of each component of this vector and s
+func (v Uint2) AddScalar(s uint32) Uint2 {
+	return Uint2{v.X + s, v.Y + s}
+}
+
+// Sub returns the component-wise difference of this vector and other
+func (v Uint2) Sub(other Uint2) Uint2 {
+	return Uint2{v.X - other.X, v.Y - other.Y}
+}
+
+// SubScalar returns the difference of each component of this vector and s
+func (v Uint2) SubScalar(s uint32) Uint2 {
+	return Uint2{v.X - s, v.Y - s}
+}
+
+// Mul returns the component-wise product of this vector and other
+func (v Uint2) Mul(other Uint2) Uint2 {
+	return Uint2{v.X * other.X, v.Y * other.Y}
+}
+
+// MulScalar returns the product of each component of this vector and s
+func (v Uint2) MulScalar(s uint32) Uint2 {
+	return Uint2{v.X * s, v.Y * s}
+}
+
+// Div returns the component-wise quotient of this vector and other
+func (v Uint2) Div(other Uint2) Uint2 {
+	return Uint2{v.X / other.X, v.Y / other.Y}
+}
+
+// DivScalar returns the quotient of each component of this vector and s
+func (v Uint2) DivScalar(s uint32) Uint2 {
+	return Uint2{v.X / s, v.Y / s}
+}
+
+// SetAdd sets this vector to the component-wise sum of it and other
+func (v *Uint2) SetAdd(other Uint2) {
+	v.X += other.X
+	v.Y += other.Y
+}
+
+// SetAddScalar sets each component of this vector to the sum of it and s
+func (v *Uint2) SetAddScalar(s uint32) {
+	v.X += s
+	v.Y += s
+}
+
+// SetSub sets this vector to the component-wise difference of it and other
+func (v *Uint2) SetSub(other Uint2) {
+	v.X -= other.X
+	v.Y -= other.Y
+}
+
+// SetSubScalar sets each component of this vector to the difference of it and s
+func (v *Uint2) SetSubScalar(s uint32) {
+	v.X -= s
+	v.Y -= s
+}
+
+// SetMul sets this vector to the component-wise product of it and other
+func (v *Uint2) SetMul(other Uint2) {
+	v.X *= other.X
+	v.Y *= other.Y
+}
+
+// SetMulScalar sets each component of this vector to the product of it and s
+func (v *Uint2) SetMulScalar(s uint32) {
+	v.X *= s
+	v.Y *= s
+}
+
+// SetDiv sets this vector to the component-wise quotient of it and other
+func (v *Uint2) SetDiv(other Uint2) {
+	v.X /= other.X
+	v.Y /= other.Y
+}
+
+// SetDivScalar sets each component of this vector to the quotient of it and s
+func (v *Uint2) SetDivScalar(s uint32) {
+	v.X /= s
+	v.Y /= s
+}
+
+// Min returns the component-wise minimum of this vector and other
+func (v Uint2) Min(other Uint2) Uint2 {
+	return Uint2{min(v.X, other.X), min(v.Y, other.Y)}
+}
+
+// Max returns the component-wise maximum of this vector and other
+func (v Uint2) Max(other Uint2) Uint2 {
+	return Uint2{max(v.X, other.X), max(v.Y, other.Y)}
+}
+
+// SetMin sets this vector to the component-wise minimum of it and other
+func (v *Uint2) SetMin(other Uint2) {
+	*v = v.Min(other)
+}
+
+// SetMax sets this vector to the component-wise maximum of it and other
+func (v *Uint2) SetMax(other Uint2) {
+	*v = v.Max(other)
+}
+
+// SetZero sets all of the vector components to zero
+func (v *Uint2) SetZero() {
+	*v = Uint2{}
+}
+
+// Negate returns the vector with each component negated,
+// wrapping around as in HLSL
+func (v Uint2) Negate() Uint2 {
+	return Uint2{-v.X, -v.Y}
+}
+
+// Dot returns the dot product of this vector and other
+func (v Uint2) Dot(other Uint2) uint32 {
+	return v.X*other.X + v.Y*other.Y
+}
+
 // Uint3 is a length 3 vector of uint32
 type Uint3 struct {
 	X uint32
@@ -42,6 +596,150 @@ type Uint3 struct {
 	Z uint32
 }
 
+// Set sets the vector components
+func (v *Uint3) Set(x, y, z uint32) {
+	v.X = x
+	v.Y = y
+	v.Z = z
+}
+
+// Add returns the component-wise sum of this vector and other
+func (v Uint3) Add(other Uint3) Uint3 {
+	return Uint3{v.X + other.X, v.Y + other.Y, v.Z + other.Z}
+}
+
+// AddScalar returns the sum of each component of this vector and s
+func (v Uint3) AddScalar(s uint32) Uint3 {
+	return Uint3{v.X + s, v.Y + s, v.Z + s}
+}
+
+// Sub returns the component-wise difference of this vector and other
+func (v Uint3) Sub(other Uint3) Uint3 {
+	return Uint3{v.X - other.X, v.Y - other.Y, v.Z - other.Z}
+}
+
+// SubScalar returns the difference of each component of this vector and s
+func (v Uint3) SubScalar(s uint32) Uint3 {
+	return Uint3{v.X - s, v.Y - s, v.Z - s}
+}
+
+// Mul returns the component-wise product of this vector and other
+func (v Uint3) Mul(other Uint3) Uint3 {
+	return Uint3{v.X * other.X, v.Y * other.Y, v.Z * other.Z}
+}
+
+// MulScalar returns the product of each component of this vector and s
+func (v Uint3) MulScalar(s uint32) Uint3 {
+	return Uint3{v.X * s, v.Y * s, v.Z * s}
+}
+
+// Div returns the component-wise quotient of this vector and other
+func (v Uint3) Div(other Uint3) Uint3 {
+	return Uint3{v.X / other.X, v.Y / other.Y, v.Z / other.Z}
+}
+
+// DivScalar returns the quotient of each component of this vector and s
+func (v Uint3) DivScalar(s uint32) Uint3 {
+	return Uint3{v.X / s, v.Y / s, v.Z / s}
+}
+
+// SetAdd sets this vector to the component-wise sum of it and other
+func (v *Uint3) SetAdd(other Uint3) {
+	v.X += other.X
+	v.Y += other.Y
+	v.Z += other.Z
+}
+
+// SetAddScalar sets each component of this vector to the sum of it and s
+func (v *Uint3) SetAddScalar(s uint32) {
+	v.X += s
+	v.Y += s
+	v.Z += s
+}
+
+// SetSub sets this vector to the component-wise difference of it and other
+func (v *Uint3) SetSub(other Uint3) {
+	v.X -= other.X
+	v.Y -= other.Y
+	v.Z -= other.Z
+}
+
+// SetSubScalar sets each component of this vector to the difference of it and s
+func (v *Uint3) SetSubScalar(s uint32) {
+	v.X -= s
+	v.Y -= s
+	v.Z -= s
+}
+
+// SetMul sets this vector to the component-wise product of it and other
+func (v *Uint3) SetMul(other Uint3) {
+	v.X *= other.X
+	v.Y *= other.Y
+	v.Z *= other.Z
+}
+
+// SetMulScalar sets each component of this vector to the product of it and s
+func (v *Uint3) SetMulScalar(s uint32) {
+	v.X *= s
+	v.Y *= s
+	v.Z *= s
+}
+
+// SetDiv sets this vector to the component-wise quotient of it and other
+func (v *Uint3) SetDiv(other Uint3) {
+	v.X /= other.X
+	v.Y /= other.Y
+	v.Z /= other.Z
+}
+
+// SetDivScalar sets each component of this vector to the quotient of it and s
+func (v *Uint3) SetDivScalar(s uint32) {
+	v.X /= s
+	v.Y /= s
+	v.Z /= s
+}
+
+// Min returns the component-wise minimum of this vector and other
+func (v Uint3) Min(other Uint3) Uint3 {
+	return Uint3{min(v.X, other.X), min(v.Y, other.Y), min(v.Z, other.Z)}
+}
+
+// Max returns the component-wise maximum of this vector and other
+func (v Uint3) Max(other Uint3) Uint3 {
+	return Uint3{max(v.X, other.X), max(v.Y, other.Y), max(v.Z, other.Z)}
+}
+
+// SetMin sets this vector to the component-wise minimum of it and other
+func (v *Uint3) SetMin(other Uint3) {
+	*v = v.Min(other)
+}
+
+// SetMax sets this vector to the component-wise maximum of it and other
+func (v *Uint3) SetMax(other Uint3) {
+	*v = v.Max(other)
+}
+
+// SetZero sets all of the vector components to zero
+func (v *Uint3) SetZero() {
+	*v = Uint3{}
+}
+
+// Negate returns the vector with each component negated,
+// wrapping around as in HLSL
+func (v Uint3) Negate() Uint3 {
+	return Uint3{-v.X, -v.Y, -v.Z}
+}
+
+// Dot returns the dot product of this vector and other
+func (v Uint3) Dot(other Uint3) uint32 {
+	return v.X*other.X + v.Y*other.Y + v.Z*other.Z
+}
+
+// Cross returns the cross product of this vector and other
+func (v Uint3) Cross(other Uint3) Uint3 {
+	return Uint3{v.Y*other.Z - v.Z*other.Y, v.Z*other.X - v.X*other.Z, v.X*other.Y - v.Y*other.X}
+}
+
 // Uint4 is a length 4 vector of uint32
 type Uint4 struct {
 	X uint32
@@ -50,6 +748,156 @@ type Uint4 struct {
 	W uint32
 }
 
+// Set sets the vector components
+func (v *Uint4) Set(x, y, z, w uint32) {
+	v.X = x
+	v.Y = y
+	v.Z = z
+	v.W = w
+}
+
+// Add returns the component-wise sum of this vector and other
+func (v Uint4) Add(other Uint4) Uint4 {
+	return Uint4{v.X + other.X, v.Y + other.Y, v.Z + other.Z, v.W + other.W}
+}
+
+// AddScalar returns the sum of each component of this vector and s
+func (v Uint4) AddScalar(s uint32) Uint4 {
+	return Uint4{v.X + s, v.Y + s, v.Z + s, v.W + s}
+}
+
+// Sub returns the component-wise difference of this vector and other
+func (v Uint4) Sub(other Uint4) Uint4 {
+	return Uint4{v.X - other.X, v.Y - other.Y, v.Z - other.Z, v.W - other.W}
+}
+
+// SubScalar returns the difference of each component of this vector and s
+func (v Uint4) SubScalar(s uint32) Uint4 {
+	return Uint4{v.X - s, v.Y - s, v.Z - s, v.W - s}
+}
+
+// Mul returns the component-wise product of this vector and other
+func (v Uint4) Mul(other Uint4) Uint4 {
+	return Uint4{v.X * other.X, v.Y * other.Y, v.Z * other.Z, v.W * other.W}
+}
+
+// MulScalar returns the product of each component of this vector and s
+func (v Uint4) MulScalar(s uint32) Uint4 {
+	return Uint4{v.X * s, v.Y * s, v.Z * s, v.W * s}
+}
+
+// Div returns the component-wise quotient of this vector and other
+func (v Uint4) Div(other Uint4) Uint4 {
+	return Uint4{v.X / other.X, v.Y / other.Y, v.Z / other.Z, v.W / other.W}
+}
+
+// DivScalar returns the quotient of each component of this vector and s
+func (v Uint4) DivScalar(s uint32) Uint4 {
+	return Uint4{v.X / s, v.Y / s, v.Z / s, v.W / s}
+}
+
+// SetAdd sets this vector to the component-wise sum of it and other
+func (v *Uint4) SetAdd(other Uint4) {
+	v.X += other.X
+	v.Y += other.Y
+	v.Z += other.Z
+	v.W += other.W
+}
+
+// SetAddScalar sets each component of this vector to the sum of it and s
+func (v *Uint4) SetAddScalar(s uint32) {
+	v.X += s
+	v.Y += s
+	v.Z += s
+	v.W += s
+}
+
+// SetSub sets this vector to the component-wise difference of it and other
+func (v *Uint4) SetSub(other Uint4) {
+	v.X -= other.X
+	v.Y -= other.Y
+	v.Z -= other.Z
+	v.W -= other.W
+}
+
+// SetSubScalar sets each component of this vector to the difference of it and s
+func (v *Uint4) SetSubScalar(s uint32) {
+	v.X -= s
+	v.Y -= s
+	v.Z -= s
+	v.W -= s
+}
+
+// SetMul sets this vector to the component-wise product of it and other
+func (v *Uint4) SetMul(other Uint4) {
+	v.X *= other.X
+	v.Y *= other.Y
+	v.Z *= other.Z
+	v.W *= other.W
+}
+
+// SetMulScalar sets each component of this vector to the product of it and s
+func (v *Uint4) SetMulScalar(s uint32) {
+	v.X *= s
+	v.Y *= s
+	v.Z *= s
+	v.W *= s
+}
+
+// SetDiv sets this vector to the component-wise quotient of it and other
+func (v *Uint4) SetDiv(other Uint4) {
+	v.X /= other.X
+	v.Y /= other.Y
+	v.Z /= other.Z
+	v.W /= other.W
+}
+
+// SetDivScalar sets each component of this vector to the quotient of it and s
+func (v *Uint4) SetDivScalar(s uint32) {
+	v.X /= s
+	v.Y /= s
+	v.Z /= s
+	v.W /= s
+}
+
+// Min returns the component-wise minimum of this vector and other
+func (v Uint4) Min(other Uint4) Uint4 {
+	return Uint4{min(v.X, other.X), min(v.Y, other.Y), min(v.Z, other.Z), min(v.W, other.W)}
+}
+
+// Max returns the component-wise maximum of this vector and other
+func (v Uint4) Max(other Uint4) Uint4 {
+	return Uint4{max(v.X, other.X), max(v.Y, other.Y), max(v.Z, other.Z), max(v.W, other.W)}
+}
+
+// SetMin sets this vector to the component-wise minimum of it and other
+func (v *Uint4) SetMin(other Uint4) {
+	*v = v.Min(other)
+}
+
+// SetMax sets this vector to the component-wise maximum of it and other
+func (v *Uint4) SetMax(other Uint4) {
+	*v = v.Max(other)
+}
+
+// SetZero sets all of the vector components to zero
+func (v *Uint4) SetZero() {
+	*v = Uint4{}
+}
+
+// Negate returns the vector with each component negated,
+// wrapping around as in HLSL
+func (v Uint4) Negate() Uint4 {
+	return Uint4{-v.X, -v.Y, -v.Z, -v.W}
+}
+
+// Dot returns the dot product of this vector and other
+func (v Uint4) Dot(other Uint4) uint32 {
+	return v.X*other.X + v.Y*other.Y + v.Z*other.Z + v.W*other.W
+}
+
+// SetFrom2 sets the X, Y components from given Uint2,
+// with Z = 0 and W = 1
 func (u *Uint4) SetFrom2(u2 Uint2) {
 	u.X = u2.X
 	u.Y = u2.Y
@@ -0,0 +1,143 @@
+// Copyright (c) 2024, Cogent Core. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sltype
+
+import "cogentcore.org/core/math32"
+
+// Float2x2 is a 2x2 matrix of float32, stored as 2 column vectors,
+// matching the default column-major memory layout of the HLSL float2x2.
+type Float2x2 struct {
+	// column 0
+	X Float2
+
+	// column 1
+	Y Float2
+}
+
+// Add returns the component-wise sum of this matrix and other
+func (m Float2x2) Add(other Float2x2) Float2x2 {
+	return Float2x2{X: m.X.Add(other.X), Y: m.Y.Add(other.Y)}
+}
+
+// Sub returns the component-wise difference of this matrix and other
+func (m Float2x2) Sub(other Float2x2) Float2x2 {
+	return Float2x2{X: m.X.Sub(other.X), Y: m.Y.Sub(other.Y)}
+}
+
+// MulScalar returns this matrix with each component multiplied by s
+func (m Float2x2) MulScalar(s float32) Float2x2 {
+	return Float2x2{X: m.X.MulScalar(s), Y: m.Y.MulScalar(s)}
+}
+
+// MulVector returns the product of this matrix and the column vector v
+func (m Float2x2) MulVector(v Float2) Float2 {
+	return m.X.MulScalar(v.X).Add(m.Y.MulScalar(v.Y))
+}
+
+// Mul returns the matrix product of this matrix and other
+func (m Float2x2) Mul(other Float2x2) Float2x2 {
+	return Float2x2{X: m.MulVector(other.X), Y: m.MulVector(other.Y)}
+}
+
+// Transpose returns the transpose of this matrix
+func (m Float2x2) Transpose() Float2x2 {
+	return Float2x2{X: math32.Vec2(m.X.X, m.Y.X), Y: math32.Vec2(m.X.Y, m.Y.Y)}
+}
+
+// Float3x3 is a 3x3 matrix of float32, stored as 3 column vectors,
+// matching the default column-major memory layout of the HLSL float3x3.
+// Each column is padded to 16 bytes, as in the std430 and std140 layouts.
+type Float3x3 struct {
+	// column 0
+	X Float3
+
+	pad0 float32
+
+	// column 1
+	Y Float3
+
+	pad1 float32
+
+	// column 2
+	Z Float3
+
+	pad2 float32
+}
+
+// Add returns the component-wise sum of this matrix and other
+func (m Float3x3) Add(other Float3x3) Float3x3 {
+	return Float3x3{X: m.X.Add(other.X), Y: m.Y.Add(other.Y), Z: m.Z.Add(other.Z)}
+}
+
+// Sub returns the component-wise difference of this matrix and other
+func (m Float3x3) Sub(other Float3x3) Float3x3 {
+	return Float3x3{X: m.X.Sub(other.X), Y: m.Y.Sub(other.Y), Z: m.Z.Sub(other.Z)}
+}
+
+// MulScalar returns this matrix with each component multiplied by s
+func (m Float3x3) MulScalar(s float32) Float3x3 {
+	return Float3x3{X: m.X.MulScalar(s), Y: m.Y.MulScalar(s), Z: m.Z.MulScalar(s)}
+}
+
+// MulVector returns the product of this matrix and the column vector v
+func (m Float3x3) MulVector(v Float3) Float3 {
+	return m.X.MulScalar(v.X).Add(m.Y.MulScalar(v.Y)).Add(m.Z.MulScalar(v.Z))
+}
+
+// Mul returns the matrix product of this matrix and other
+func (m Float3x3) Mul(other Float3x3) Float3x3 {
+	return Float3x3{X: m.MulVector(other.X), Y: m.MulVector(other.Y), Z: m.MulVector(other.Z)}
+}
+
+// Transpose returns the transpose of this matrix
+func (m Float3x3) Transpose() Float3x3 {
+	return Float3x3{X: math32.Vec3(m.X.X, m.Y.X, m.Z.X), Y: math32.Vec3(m.X.Y, m.Y.Y, m.Z.Y), Z: math32.Vec3(m.X.Z, m.Y.Z, m.Z.Z)}
+}
+
+// Float4x4 is a 4x4 matrix of float32, stored as 4 column vectors,
+// matching the default column-major memory layout of the HLSL float4x4.
+type Float4x4 struct {
+	// column 0
+	X Float4
+
+	// column 1
+	Y Float4
+
+	// column 2
+	Z Float4
+
+	// column 3
+	W Float4
+}
+
+// Add returns the component-wise sum of this matrix and other
+func (m Float4x4) Add(other Float4x4) Float4x4 {
+	return Float4x4{X: m.X.Add(other.X), Y: m.Y.Add(other.Y), Z: m.Z.Add(other.Z), W: m.W.Add(other.W)}
+}
+
+// Sub returns the component-wise difference of this matrix and other
+func (m Float4x4) Sub(other Float4x4) Float4x4 {
+	return Float4x4{X: m.X.Sub(other.X), Y: m.Y.Sub(other.Y), Z: m.Z.Sub(other.Z), W: m.W.Sub(other.W)}
+}
+
+// MulScalar returns this matrix with each component multiplied by s
+func (m Float4x4) MulScalar(s float32) Float4x4 {
+	return Float4x4{X: m.X.MulScalar(s), Y: m.Y.MulScalar(s), Z: m.Z.MulScalar(s), W: m.W.MulScalar(s)}
+}
+
+// MulVector returns the product of this matrix and the column vector v
+func (m Float4x4) MulVector(v Float4) Float4 {
+	return m.X.MulScalar(v.X).Add(m.Y.MulScalar(v.Y)).Add(m.Z.MulScalar(v.Z)).Add(m.W.MulScalar(v.W))
+}
+
+// Mul returns the matrix product of this matrix and other
+func (m Float4x4) Mul(other Float4x4) Float4x4 {
+	return Float4x4{X: m.MulVector(other.X), Y: m.MulVector(other.Y), Z: m.MulVector(other.Z), W: m.MulVector(other.W)}
+}
+
+// Transpose returns the transpose of this matrix
+func (m Float4x4) Transpose() Float4x4 {
+	return Float4x4{X: math32.Vec4(m.X.X, m.Y.X, m.Z.X, m.W.X), Y: math32.Vec4(m.X.Y, m.Y.Y, m.Z.Y, m.W.Y), Z: math32.Vec4(m.X.Z, m.Y.Z, m.Z.Z, m.W.Z), W: math32.Vec4(m.X.W, m.Y.W, m.Z.W, m.W.W)}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2024, Cogent Core. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sltype
+
+import (
+	"testing"
+	"unsafe"
+
+	"cogentcore.org/core/math32"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntVectors(t *testing.T) {
+	a := Int3{1, -2, 3}
+	b := Int3{4, 5, -6}
+	assert.Equal(t, Int3{5, 3, -3}, a.Add(b))
+	assert.Equal(t, Int3{-3, -7, 9}, a.Sub(b))
+	assert.Equal(t, Int3{4, -10, -18}, a.Mul(b))
+	assert.Equal(t, Int3{2, -4, 6}, a.MulScalar(2))
+	assert.Equal(t, Int3{1, -2, -6}, a.Min(b))
+	assert.Equal(t, Int3{4, 5, 3}, a.Max(b))
+	assert.Equal(t, Int3{-1, 2, -3}, a.Negate())
+	assert.Equal(t, int32(-24), a.Dot(b))
+	assert.Equal(t, Int3{-3, 18, 13}, a.Cross(b))
+	assert.Equal(t, int32(0), a.Cross(b).Dot(a))
+	assert.Equal(t, int32(-5), Int2{1, -2}.Dot(Int2{-1, 2}))
+	a.SetAdd(b)
+	assert.Equal(t, Int3{5, 3, -3}, a)
+	a.SetZero()
+	assert.Equal(t, Int3{}, a)
+
+	u := Uint4{1, 2, 3, 4}
+	assert.Equal(t, Uint4{2, 4, 6, 8}, u.Add(u))
+	assert.Equal(t, Uint4{0, 1, 1, 2}, u.DivScalar(2))
+	assert.Equal(t, uint32(30), u.Dot(u))
+	u.Set(5, 6, 7, 8)
+	assert.Equal(t, Uint4{5, 6, 7, 8}, u)
+	u.SetFrom2(Uint2{9, 10})
+	assert.Equal(t, Uint4{9, 10, 0, 1}, u)
+}
+
+func TestFloatCross(t *testing.T) {
+	x := math32.Vec3(1, 0, 0)
+	y := math32.Vec3(0, 1, 0)
+	assert.Equal(t, math32.Vec3(0, 0, 1), x.Cross(y))
+	assert.Equal(t, float32(5), math32.Vec2(3, 4).Length())
+}
+
+func TestMatrices(t *testing.T) {
+	m := Float4x4{X: math32.Vec4(1, 2, 3, 4), Y: math32.Vec4(5, 6, 7, 8), Z: math32.Vec4(9, 10, 11, 12), W: math32.Vec4(13, 14, 15, 16)}
+	n := Float4x4{X: math32.Vec4(2, 0, 1, 0), Y: math32.Vec4(0, 1, 0, 3), Z: math32.Vec4(1, 1, 0, 0), W: math32.Vec4(0, 0, 2, 1)}
+
+	// math32.Matrix4 is column-major, so it has the same memory layout
+	var mm, nm math32.Matrix4
+	mm.FromArray([]float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, 0)
+	nm.FromArray([]float32{2, 0, 1, 0, 0, 1, 0, 3, 1, 1, 0, 0, 0, 0, 2, 1}, 0)
+	pm := mm.Mul(&nm)
+	p := m.Mul(n)
+	assert.Equal(t, pm[0:4], []float32{p.X.X, p.X.Y, p.X.Z, p.X.W})
+	assert.Equal(t, pm[12:16], []float32{p.W.X, p.W.Y, p.W.Z, p.W.W})
+
+	v := math32.Vec4(1, 1, 0, 2)
+	assert.Equal(t, v.MulMatrix4(&mm), m.MulVector(v))
+	assert.Equal(t, m, m.Transpose().Transpose())
+	assert.Equal(t, math32.Vec4(1, 5, 9, 13), m.Transpose().X)
+	assert.Equal(t, m.MulScalar(2), m.Add(m))
+	assert.Equal(t, Float4x4{}, m.Sub(m))
+
+	r := Float3x3{X: math32.Vec3(0, 1, 0), Y: math32.Vec3(-1, 0, 0), Z: math32.Vec3(0, 0, 1)} // 90 deg rotation
+	assert.Equal(t, math32.Vec3(0, 1, 0), r.MulVector(math32.Vec3(1, 0, 0)))
+	assert.Equal(t, math32.Vec3(-1, 0, 0), r.Mul(r).MulVector(math32.Vec3(1, 0, 0)))
+	assert.Equal(t, uintptr(48), unsafe.Sizeof(r))
+
+	s := Float2x2{X: math32.Vec2(1, 2), Y: math32.Vec2(3, 4)}
+	assert.Equal(t, math32.Vec2(7, 10), s.MulVector(math32.Vec2(1, 2)))
+	assert.Equal(t, Float2x2{X: math32.Vec2(1, 3), Y: math32.Vec2(2, 4)}, s.Transpose())
+}
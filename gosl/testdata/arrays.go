@@ -0,0 +1,52 @@
+package test
+
+//gosl:start arrays
+
+// Chan has the parameters for one channel
+type Chan struct {
+
+	// channel gain
+	Gbar float32
+
+	// reversal potential
+	Erev float32
+
+	pad, pad1 float32
+}
+
+// ArrayParams has fixed-size array fields
+type ArrayParams struct {
+
+	// gains for each pathway
+	Gains [4]float32
+
+	// channels
+	Chans [2]Chan
+
+	// index table
+	Idxs [2][4]int32
+}
+
+// SumGains returns the sum of the gains times the given factors
+func (ap *ArrayParams) SumGains(facs *[4]float32) float32 {
+	sum := float32(0)
+	for i := 0; i < 4; i++ {
+		sum += ap.Gains[i] * facs[i]
+	}
+	return sum
+}
+
+// SetGains sets all the gains, using local arrays
+func (ap *ArrayParams) SetGains(g float32) {
+	var loc [4]float32
+	ones := [4]float32{1, 1, 1, 1}
+	zeros := [2][2]int32{}
+	for i := 0; i < 4; i++ {
+		loc[i] = g * ones[i]
+		ap.Gains[i] = loc[i]
+	}
+	ap.Chans[0].Gbar = g
+	ap.Idxs[1][2] = zeros[0][1]
+}
+
+//gosl:end arrays
@@ -0,0 +1,40 @@
+package test
+
+import "github.com/tomas-mraz/vgpu/gosl/slbool"
+
+//gosl:start bits
+
+const (
+	// Spiked is the flag for a neuron that spiked
+	Spiked uint32 = iota
+
+	// Bursting is the flag for a bursting neuron
+	Bursting
+)
+
+// Neuron has packed boolean flags
+type Neuron struct {
+
+	// boolean flags
+	Flags slbool.Bits32
+
+	// number of flags set
+	NFlags uint32
+
+	pad, pad1 float32
+}
+
+// Update updates the flags
+func (nr *Neuron) Update(spike bool) {
+	if spike {
+		nr.Flags.Set(Spiked)
+	} else {
+		nr.Flags.Clear(Spiked)
+	}
+	if nr.Flags.Get(Spiked) && !nr.Flags.Get(Bursting+1) {
+		nr.Flags.Toggle(Bursting)
+	}
+	nr.NFlags = nr.Flags.Count()
+}
+
+//gosl:end bits
@@ -0,0 +1,60 @@
+//gosl flags -exclude=Update,Defaults
+
+package test
+
+import "fmt"
+
+//gosl:start directives
+
+// DirParams are the kernel params
+//
+//gosl:name KernelParams
+type DirParams struct {
+	Gain float32
+	Off  float32
+
+	pad, pad1 float32
+}
+
+// Defaults is needed on the GPU, even though it is excluded
+//
+//gosl:keep
+func (dp *DirParams) Defaults() {
+	dp.Gain = 1
+	dp.Off = 0
+}
+
+// Update is only used on the CPU
+//
+//gosl:skip
+func (dp *DirParams) Update() {
+	dp.Off = dp.Gain * 0.1
+}
+
+// Compute computes the output with a different HLSL name
+//
+//gosl:name ComputeOut
+func (dp *DirParams) Compute(x float32) float32 {
+	y := dp.Gain*x + dp.Off
+	//gosl:cpu-only
+	if y < 0 {
+		fmt.Println("negative output:", y)
+	}
+	//gosl:end cpu-only
+	return y
+}
+
+//gosl:end directives
+
+//gosl:hlsl directives
+/*
+[[vk::binding(0, 0)]] StructuredBuffer<KernelParams> Params;
+[[vk::binding(0, 1)]] RWStructuredBuffer<float> Data;
+[numthreads(64, 1, 1)]
+void main(uint3 idx : SV_DispatchThreadID) {
+    KernelParams pars = Params[0];
+    pars.Defaults();
+    Data[idx.x] = pars.ComputeOut(Data[idx.x]);
+}
+*/
+//gosl:end directives
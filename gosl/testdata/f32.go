@@ -0,0 +1,26 @@
+//gosl flags -f32
+
+package test
+
+import "math"
+
+//gosl:start f32
+
+// Decay is the decay constant
+const Decay = 0.95
+
+// Rate is a typed float64 constant
+const Rate float64 = 0.1
+
+// Integrate integrates the value using float64 reference code
+func Integrate(v, dt float64) float64 {
+	nv := v * Decay
+	ex := math.Exp(-dt * Rate)
+	sum := float64(0)
+	for i := 0; i < 4; i++ {
+		sum += nv * ex
+	}
+	return math.Sqrt(sum)
+}
+
+//gosl:end f32
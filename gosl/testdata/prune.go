@@ -0,0 +1,64 @@
+//gosl flags -prune
+
+package test
+
+//gosl:start prune
+
+// PruneModes are the modes, only some of which are used
+type PruneModes int32
+
+const (
+	PruneOff PruneModes = iota
+	PruneOn
+)
+
+// UnusedConst is not used by the kernel
+const UnusedConst = 42
+
+// Scale is used by the kernel via Compute
+func Scale(x float32) float32 {
+	return 2 * x
+}
+
+// Unused is not called by anything
+func Unused(x float32) float32 {
+	return Scale(x) + UnusedConst
+}
+
+// PruneParams are the kernel params
+type PruneParams struct {
+	Mode PruneModes
+	Gain float32
+
+	pad, pad1 float32
+}
+
+// Compute is called from the kernel main
+func (pp *PruneParams) Compute(x *float32) {
+	if pp.Mode == PruneOn {
+		*x = Scale(*x) * pp.Gain
+	}
+}
+
+// NotCalled is a method that is never called
+func (pp *PruneParams) NotCalled() float32 {
+	return pp.Gain
+}
+
+// UnusedStruct is not used by anything
+type UnusedStruct struct {
+	A, B, C, D float32
+}
+
+//gosl:end prune
+
+//gosl:hlsl prune
+/*
+[[vk::binding(0, 0)]] StructuredBuffer<PruneParams> Params;
+[[vk::binding(0, 1)]] RWStructuredBuffer<float> Data;
+[numthreads(64, 1, 1)]
+void main(uint3 idx : SV_DispatchThreadID) {
+    Params[0].Compute(Data[idx.x]);
+}
+*/
+//gosl:end prune
@@ -0,0 +1,43 @@
+package test
+
+//gosl:start uniform
+
+// UniParams are bound as a uniform buffer, where the
+// array elements have a 16 byte stride in std140
+type UniParams struct {
+
+	// gains for each pathway
+	Gains [4]float32
+}
+
+// LayParams are marked for std140 checking via directive
+//
+//gosl:layout std140
+type LayParams struct {
+
+	// rate constant
+	Tau float32
+
+	pad, pad1, pad2 float32
+}
+
+// StoreData is bound as a storage buffer
+type StoreData struct {
+
+	// values
+	Vals [4]float32
+}
+
+//gosl:end uniform
+
+//gosl:hlsl uniform
+/*
+[[vk::binding(0, 0)]] ConstantBuffer<UniParams> Params;
+[[vk::binding(0, 1)]] StructuredBuffer<LayParams> Lays;
+[[vk::binding(0, 2)]] RWStructuredBuffer<StoreData> Data;
+[numthreads(1, 1, 1)]
+void main(uint3 idx : SV_DispatchThreadID) {
+    Data[idx.x].Vals[0] = Params.Gains[0] * Lays[0].Tau;
+}
+*/
+//gosl:end uniform
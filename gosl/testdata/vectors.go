@@ -0,0 +1,57 @@
+package test
+
+import (
+	"cogentcore.org/core/math32"
+	"github.com/tomas-mraz/vgpu/gosl/sltype"
+)
+
+//gosl:start vectors
+
+// Particle has vector and matrix fields
+type Particle struct {
+
+	// position
+	Pos sltype.Float4
+
+	// velocity
+	Vel sltype.Float3
+
+	// mass
+	Mass float32
+
+	// grid cell index
+	Cell sltype.Int4
+
+	// flags
+	Flags sltype.Uint4
+
+	// rotation
+	Rot sltype.Float4x4
+}
+
+// Step updates the particle using vector and matrix operations
+func (pt *Particle) Step(dt float32, force sltype.Float3) {
+	acc := force.DivScalar(pt.Mass)
+	pt.Vel.SetAdd(acc.MulScalar(dt))
+	pt.Vel.SetMin(sltype.Float3{X: 10, Y: 10, Z: 10})
+	up := sltype.Float3{Z: 1}
+	side := pt.Vel.Cross(up).Normal()
+	speed := pt.Vel.Length()
+	pt.Pos.X += speed * side.X
+	pt.Pos = pt.Rot.MulVector(pt.Pos.Add(math32.Vec4(dt, dt, dt, 0)))
+	pt.Cell = sltype.Int4{1, 2, 3, 4}.Mul(pt.Cell).Max(sltype.Int4{})
+	pt.Flags.Set(1, 2, 3, pt.Flags.W)
+	ci := sltype.Int3{pt.Cell.X, pt.Cell.Y, 1}
+	cx := sltype.Int3{X: 1}
+	pt.Cell.Z = ci.Cross(cx).Add(ci).Dot(ci)
+	fu := sltype.Uint3{1, 2, 3}
+	fz := sltype.Uint3{Z: 1}
+	pt.Flags.X = fu.Cross(fz).X
+	nd := pt.Pos.Dot(pt.Pos) + pt.Vel.LengthSquared()
+	rot := sltype.Float4x4{X: pt.Pos, Y: pt.Pos.Negate()}
+	pt.Rot = pt.Rot.Mul(rot.Transpose()).MulScalar(nd)
+	pt.Pos.Y = pt.Rot.Y.Z
+	pt.Rot.W = pt.Pos.MulScalar(nd)
+}
+
+//gosl:end vectors
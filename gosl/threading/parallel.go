@@ -0,0 +1,153 @@
+// Copyright (c) 2024, Cogent Core. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package threading
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// NThreads returns the number of threads to use for given requested
+// number: runtime.GOMAXPROCS(0) if nThreads <= 0.
+func NThreads(nThreads int) int {
+	if nThreads <= 0 {
+		return runtime.GOMAXPROCS(0)
+	}
+	return nThreads
+}
+
+// ChunkSize returns the default chunk size for dynamic scheduling of
+// total items across nThreads: 8 chunks per thread, at least 1 item.
+func ChunkSize(total, nThreads int) int {
+	return max(1, total/(8*NThreads(nThreads)))
+}
+
+// forChunks calls fun for each chunk [st, ed) of the [0, total) range,
+// with chunk index ci, using nThreads goroutines that each take the next
+// chunk from a shared counter until all are done (dynamic scheduling),
+// so that faster threads do more chunks.  Stops taking new chunks when
+// ctx is done, returning ctx.Err().
+func forChunks(ctx context.Context, total, nThreads, chunk int, fun func(ci, st, ed int)) error {
+	if total <= 0 {
+		return ctx.Err()
+	}
+	if chunk <= 0 {
+		chunk = ChunkSize(total, nThreads)
+	}
+	nChunks := (total + chunk - 1) / chunk
+	nThreads = min(NThreads(nThreads), nChunks)
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	wg.Add(nThreads)
+	for range nThreads {
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				ci := int(next.Add(1) - 1)
+				if ci >= nChunks {
+					return
+				}
+				st := ci * chunk
+				fun(ci, st, min(st+chunk, total))
+			}
+		}()
+	}
+	wg.Wait()
+	return ctx.Err()
+}
+
+// ParallelFor calls fun for each index in the [0, total) range, using
+// nThreads goroutines (GOMAXPROCS if <= 0), with dynamic scheduling of
+// chunks of indexes, so it balances the load when the cost of fun varies
+// across indexes, unlike [ParallelRun].
+func ParallelFor(fun func(i int), total, nThreads int) {
+	ParallelForContext(context.Background(), fun, total, nThreads, 0)
+}
+
+// ParallelForContext is [ParallelFor] with the given chunk size
+// (default [ChunkSize] if <= 0), which stops processing new chunks
+// when ctx is done, returning ctx.Err().
+func ParallelForContext(ctx context.Context, fun func(i int), total, nThreads, chunk int) error {
+	return forChunks(ctx, total, nThreads, chunk, func(ci, st, ed int) {
+		for i := st; i < ed; i++ {
+			fun(i)
+		}
+	})
+}
+
+// ParallelFor2D calls fun for each x, y index in the nx * ny range,
+// as in [ParallelFor], with x varying fastest, matching the
+// shape of a 2D compute shader dispatch.
+func ParallelFor2D(fun func(x, y int), nx, ny, nThreads int) {
+	ParallelFor2DContext(context.Background(), fun, nx, ny, nThreads, 0)
+}
+
+// ParallelFor2DContext is [ParallelFor2D] with a chunk size and context,
+// as in [ParallelForContext].
+func ParallelFor2DContext(ctx context.Context, fun func(x, y int), nx, ny, nThreads, chunk int) error {
+	return ParallelForContext(ctx, func(i int) {
+		fun(i%nx, i/nx)
+	}, nx*ny, nThreads, chunk)
+}
+
+// ParallelFor3D calls fun for each x, y, z index in the nx * ny * nz range,
+// as in [ParallelFor], with x varying fastest and z slowest, matching the
+// shape of a 3D compute shader dispatch.
+func ParallelFor3D(fun func(x, y, z int), nx, ny, nz, nThreads int) {
+	ParallelFor3DContext(context.Background(), fun, nx, ny, nz, nThreads, 0)
+}
+
+// ParallelFor3DContext is [ParallelFor3D] with a chunk size and context,
+// as in [ParallelForContext].
+func ParallelFor3DContext(ctx context.Context, fun func(x, y, z int), nx, ny, nz, nThreads, chunk int) error {
+	nxy := nx * ny
+	return ParallelForContext(ctx, func(i int) {
+		xy := i % nxy
+		fun(xy%nx, xy/nx, i/nxy)
+	}, nxy*nz, nThreads, chunk)
+}
+
+// ParallelReduce returns the reduction of the values returned by fun for
+// each index in the [0, total) range, combined with the reduce function,
+// using nThreads goroutines (GOMAXPROCS if <= 0) with dynamic scheduling
+// as in [ParallelFor].  Each chunk of indexes is reduced in order, and then
+// the chunk values are reduced in order, so the result is deterministic for
+// a given chunk size, even if reduce is not associative (e.g., float sums).
+// Returns the zero value of T if total is 0.
+func ParallelReduce[T any](fun func(i int) T, reduce func(a, b T) T, total, nThreads int) T {
+	r, _ := ParallelReduceContext(context.Background(), fun, reduce, total, nThreads, 0)
+	return r
+}
+
+// ParallelReduceContext is [ParallelReduce] with the given chunk size
+// (default [ChunkSize] if <= 0), which stops processing new chunks
+// when ctx is done, returning ctx.Err() and the zero value of T.
+func ParallelReduceContext[T any](ctx context.Context, fun func(i int) T, reduce func(a, b T) T, total, nThreads, chunk int) (T, error) {
+	var r T
+	if total <= 0 {
+		return r, ctx.Err()
+	}
+	if chunk <= 0 {
+		chunk = ChunkSize(total, nThreads)
+	}
+	parts := make([]T, (total+chunk-1)/chunk)
+	err := forChunks(ctx, total, nThreads, chunk, func(ci, st, ed int) {
+		acc := fun(st)
+		for i := st + 1; i < ed; i++ {
+			acc = reduce(acc, fun(i))
+		}
+		parts[ci] = acc
+	})
+	if err != nil {
+		return r, err
+	}
+	r = parts[0]
+	for _, p := range parts[1:] {
+		r = reduce(r, p)
+	}
+	return r, nil
+}
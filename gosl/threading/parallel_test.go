@@ -0,0 +1,156 @@
+// Copyright (c) 2024, Cogent Core. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package threading
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParallelFor(t *testing.T) {
+	for _, total := range []int{0, 1, 7, 100, 10007} {
+		for _, nThreads := range []int{0, 1, 3, 64} {
+			counts := make([]int32, total)
+			ParallelFor(func(i int) {
+				atomic.AddInt32(&counts[i], 1)
+			}, total, nThreads)
+			for i, c := range counts {
+				if c != 1 {
+					t.Fatalf("total: %d nThreads: %d: index %d called %d times", total, nThreads, i, c)
+				}
+			}
+		}
+	}
+}
+
+func TestParallelForChunk(t *testing.T) {
+	total := 1000
+	for _, chunk := range []int{1, 3, 999, 1000, 5000} {
+		counts := make([]int32, total)
+		err := ParallelForContext(context.Background(), func(i int) {
+			atomic.AddInt32(&counts[i], 1)
+		}, total, 4, chunk)
+		assert.NoError(t, err)
+		for i, c := range counts {
+			if c != 1 {
+				t.Fatalf("chunk: %d: index %d called %d times", chunk, i, c)
+			}
+		}
+	}
+}
+
+func TestParallelFor2D3D(t *testing.T) {
+	nx, ny, nz := 7, 5, 3
+	counts := make([]int32, nx*ny*nz)
+	ParallelFor2D(func(x, y int) {
+		assert.True(t, x >= 0 && x < nx && y >= 0 && y < ny)
+		atomic.AddInt32(&counts[y*nx+x], 1)
+	}, nx, ny, 4)
+	for i, c := range counts[:nx*ny] {
+		assert.Equal(t, int32(1), c, "index: %d", i)
+	}
+
+	counts = make([]int32, nx*ny*nz)
+	ParallelFor3D(func(x, y, z int) {
+		assert.True(t, x >= 0 && x < nx && y >= 0 && y < ny && z >= 0 && z < nz)
+		atomic.AddInt32(&counts[(z*ny+y)*nx+x], 1)
+	}, nx, ny, nz, 4)
+	for i, c := range counts {
+		assert.Equal(t, int32(1), c, "index: %d", i)
+	}
+}
+
+func TestParallelReduce(t *testing.T) {
+	total := 10000
+	sum := ParallelReduce(func(i int) int { return i }, func(a, b int) int { return a + b }, total, 0)
+	assert.Equal(t, total*(total-1)/2, sum)
+
+	smx := math.Inf(-1)
+	for i := range total {
+		smx = math.Max(smx, math.Sin(float64(i)))
+	}
+	mx := ParallelReduce(func(i int) float64 { return math.Sin(float64(i)) }, math.Max, total, 3)
+	assert.Equal(t, smx, mx)
+
+	assert.Equal(t, 0, ParallelReduce(func(i int) int { return 1 }, func(a, b int) int { return a + b }, 0, 4))
+
+	// float sums are deterministic for a given chunk size
+	fsum := func(nThreads int) float32 {
+		r, err := ParallelReduceContext(context.Background(), func(i int) float32 { return 1 / float32(i+1) }, func(a, b float32) float32 { return a + b }, total, nThreads, 16)
+		assert.NoError(t, err)
+		return r
+	}
+	f1 := fsum(1)
+	for range 10 {
+		assert.Equal(t, f1, fsum(8))
+	}
+}
+
+func TestParallelForContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var n atomic.Int32
+	err := ParallelForContext(ctx, func(i int) {
+		if n.Add(1) == 10 {
+			cancel()
+		}
+	}, 100000, 4, 1)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Less(t, n.Load(), int32(100000))
+
+	_, err = ParallelReduceContext(ctx, func(i int) int { return i }, func(a, b int) int { return a + b }, 100, 4, 0)
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
+// work does an amount of work proportional to i, so that the load
+// is unbalanced across the static ranges of ParallelRun
+func work(i int) float64 {
+	s := 0.0
+	for j := 0; j < i/10; j++ {
+		s += math.Sqrt(float64(j))
+	}
+	return s
+}
+
+const benchN = 20000
+
+func BenchmarkSerial(b *testing.B) {
+	res := make([]float64, benchN)
+	for range b.N {
+		for i := range benchN {
+			res[i] = work(i)
+		}
+	}
+}
+
+func BenchmarkParallelRun(b *testing.B) {
+	res := make([]float64, benchN)
+	for range b.N {
+		ParallelRun(func(st, ed int) {
+			for i := st; i < ed; i++ {
+				res[i] = work(i)
+			}
+		}, benchN, NThreads(0))
+	}
+}
+
+func BenchmarkParallelFor(b *testing.B) {
+	res := make([]float64, benchN)
+	for range b.N {
+		ParallelFor(func(i int) {
+			res[i] = work(i)
+		}, benchN, 0)
+	}
+}
+
+func BenchmarkParallelReduce(b *testing.B) {
+	for range b.N {
+		ParallelReduce(func(i int) float64 { return work(i) }, func(a, b float64) float64 { return a + b }, benchN, 0)
+	}
+}
@@ -1,3 +1,8 @@
+// Package threading provides helpers for running Go code in parallel on
+// the CPU, e.g., for the CPU version of gosl compute code:
+// [ParallelFor] and [ParallelReduce] (with 2D, 3D and context versions)
+// use dynamic scheduling of chunks of items to balance the load, while
+// [ParallelRun] statically divides the items among the threads.
 package threading
 
 import (
@@ -376,6 +376,7 @@ func (gp *GPU) CheckGPUOpts(feats *vk.PhysicalDeviceFeatures, opts *GPUOpts, rep
 		case OptInheritedQueries:
 			hasOpt = (feats.InheritedQueries == vk.True)
 		}
+		//enums:nonexhaustive
 		switch st {
 		case Required:
 			if hasOpt {
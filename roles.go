@@ -49,6 +49,7 @@ func (vr VarRoles) VkDescriptorStatic() vk.DescriptorType {
 	return StaticRoleDescriptors[vr]
 }
 
+//enums:nonexhaustive
 var RoleDescriptors = map[VarRoles]vk.DescriptorType{
 	Uniform:      vk.DescriptorTypeUniformBufferDynamic,
 	Storage:      vk.DescriptorTypeStorageBufferDynamic,
@@ -59,6 +60,8 @@ var RoleDescriptors = map[VarRoles]vk.DescriptorType{
 }
 
 // For static variable binding
+//
+//enums:nonexhaustive
 var StaticRoleDescriptors = map[VarRoles]vk.DescriptorType{
 	Uniform:      vk.DescriptorTypeUniformBuffer,
 	Storage:      vk.DescriptorTypeStorageBuffer,
@@ -69,6 +72,9 @@ var StaticRoleDescriptors = map[VarRoles]vk.DescriptorType{
 }
 
 // RoleBuffers maps VarRoles onto type of memory buffer
+// (Push constants do not use a buffer).
+//
+//enums:nonexhaustive
 var RoleBuffers = map[VarRoles]BuffTypes{
 	UndefVarRole: StorageBuff,
 	Vertex:       VtxIndexBuff,
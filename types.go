@@ -74,6 +74,7 @@ func (tp Types) VkIndexType() vk.IndexType {
 
 // Bytes returns number of bytes for this type
 func (tp Types) Bytes() int {
+	//enums:nonexhaustive
 	switch tp {
 	case Float32Matrix4:
 		return 64
@@ -111,7 +112,10 @@ var FormatSizes = map[vk.Format]int{
 	vk.FormatD24UnormS8Uint:     4,
 }
 
-// VulkanTypes maps vgpu.Types to vulkan types
+// VulkanTypes maps vgpu.Types to vulkan types; the matrix and Struct
+// types do not have a single corresponding vulkan format.
+//
+//enums:nonexhaustive
 var VulkanTypes = map[Types]vk.Format{
 	UndefinedType:   vk.FormatUndefined,
 	Bool32:          vk.FormatR32Uint,
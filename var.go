@@ -9,6 +9,7 @@ import (
 	"log"
 
 	vk "github.com/goki/vulkan"
+	"github.com/tomas-mraz/vgpu/gosl/alignsl/layout"
 )
 
 // Var specifies a variable used in a pipeline, accessed in shader programs.
@@ -65,6 +66,9 @@ type Var struct {
 
 	// offset -- only for push constants
 	Offset int `edit:"-"`
+
+	// memory layout of the fields of a Struct var, recorded by VarSet.AddStructType -- nil otherwise
+	StructLayout *layout.StructLayout `edit:"-"`
 }
 
 // Init initializes the main values
@@ -7,9 +7,11 @@ package vgpu
 import (
 	"fmt"
 	"log"
+	"reflect"
 	"unsafe"
 
 	vk "github.com/goki/vulkan"
+	"github.com/tomas-mraz/vgpu/gosl/alignsl/layout"
 	"github.com/tomas-mraz/vgpu/szalloc"
 )
 
@@ -107,6 +109,27 @@ func (st *VarSet) AddStruct(name string, size int, arrayN int, role VarRoles, sh
 	return vr
 }
 
+// AddStructType adds a new struct variable of given Go struct type,
+// array size, role, set, and shaders where used.
+// The type is checked for GPU memory alignment according to the
+// layout rules for the role (std140 for Uniform, else std430),
+// using [layout.CheckType], returning an error describing each
+// violation, without adding the var.  Otherwise the size and field
+// layout of the type are recorded in the var.
+func (st *VarSet) AddStructType(name string, typ reflect.Type, arrayN int, role VarRoles, shaders ...ShaderTypes) (*Var, error) {
+	if err := layout.CheckType(typ, layout.RoleLayout(role.String())); err != nil {
+		err = fmt.Errorf("vgpu.VarSet:AddStructType var: %s role: %s: %w", name, role.String(), err)
+		if Debug {
+			log.Println(err)
+		}
+		return nil, err
+	}
+	sl := layout.TypeLayout(typ)
+	vr := st.AddStruct(name, int(sl.Size), arrayN, role, shaders...)
+	vr.StructLayout = sl
+	return vr, nil
+}
+
 // Config must be called after all variables have been added.
 // configures binding / location for all vars based on sequential order.
 // also does validation and returns error message.
@@ -0,0 +1,50 @@
+// Copyright (c) 2024, Cogent Core. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vgpu
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddStructType(t *testing.T) {
+	type Params struct {
+		Tau   float32
+		Dt    float32
+		Count int32
+		pad   float32
+	}
+	type BadParams struct {
+		Tau  float32
+		On   bool
+		Vals [2]float32
+	}
+	vs := &VarSet{}
+	vr, err := vs.AddStructType("Params", reflect.TypeOf(Params{}), 1, Uniform, ComputeShader)
+	assert.NoError(t, err)
+	assert.Equal(t, Struct, vr.Type)
+	assert.Equal(t, Uniform, vr.Role)
+	assert.Equal(t, 16, vr.SizeOf)
+	assert.Equal(t, int64(16), vr.StructLayout.Size)
+	assert.Equal(t, "Count", vr.StructLayout.Fields[2].Name)
+	assert.Equal(t, int64(8), vr.StructLayout.Fields[2].Offset)
+	assert.Same(t, vr, vs.VarMap["Params"])
+
+	// misaligned struct is not added
+	vr, err = vs.AddStructType("Bad", reflect.TypeOf(BadParams{}), 1, Uniform, ComputeShader)
+	assert.Nil(t, vr)
+	assert.ErrorContains(t, err, "vgpu.VarSet:AddStructType var: Bad role: Uniform: alignsl: std140 layout errors in type BadParams:")
+	assert.ErrorContains(t, err, "On:  basic type not allowed: bool")
+	assert.ErrorContains(t, err, "Vals:  array element stride: 4 != std140 stride: 16")
+	assert.Len(t, vs.Vars, 1)
+	assert.NotContains(t, vs.VarMap, "Bad")
+
+	// the storage buffer layout allows the 4 byte array stride
+	_, err = vs.AddStructType("Bad", reflect.TypeOf(BadParams{}), 1, Storage, ComputeShader)
+	assert.ErrorContains(t, err, "vgpu.VarSet:AddStructType var: Bad role: Storage: alignsl: std430 layout errors in type BadParams:")
+	assert.NotContains(t, err.Error(), "Vals")
+}